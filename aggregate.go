@@ -0,0 +1,230 @@
+package typedb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// aggregateNode tracks the children already appended to one parent, so repeated rows are merged.
+type aggregateNode struct {
+	children map[string]map[string]*aggregateChild // Slice field tag -> child key -> child
+}
+
+// aggregateChild is a child appended to a slice field, with its own children.
+type aggregateChild struct {
+	node  aggregateNode
+	index int // Index in the parent's slice field
+}
+
+// QueryAggregate executes a join query and aggregates its rows into parent models with child slices.
+// Rows are grouped by the parent's load:"primary" column, in any order; each parent is returned once,
+// in the order it first appears. AfterLoad hooks run once all rows are aggregated: on every
+// aggregated child after its own children, then on each parent.
+//
+// Slice fields of structs (or struct pointers) whose type has db tags are filled from the columns
+// prefixed with the field's db tag, as for nested struct fields ("posts.id" or "posts_id" for
+// a field tagged db:"posts"). Children are identified by their own load:"primary" field, so a child
+// repeated by other joins is appended once. Rows whose child columns are all NULL (LEFT JOIN without
+// a match) add no child. Children are aggregated recursively: grandchildren use the child's prefix
+// followed by their own (e.g., "posts.comments.id").
+// T must be a pointer type (e.g., *User).
+//
+// Example:
+//
+//	type User struct {
+//	    typedb.Model
+//	    ID    int64  `db:"id" load:"primary"`
+//	    Posts []Post `db:"posts"`
+//	}
+//
+//	users, err := typedb.QueryAggregate[*User](ctx, db,
+//	    `SELECT u.id, p.id AS "posts.id", p.title AS "posts.title"
+//	     FROM users u LEFT JOIN posts p ON p.user_id = u.id`)
+func QueryAggregate[T ModelInterface](ctx context.Context, exec Executor, query string, args ...any) ([]T, error) {
+	var zero T
+	modelType := reflect.TypeOf(zero)
+	if modelType == nil || modelType.Kind() != reflect.Ptr || modelType.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("typedb: QueryAggregate requires a pointer to struct type")
+	}
+	keyTag, err := aggregateKeyTag(modelType.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := exec.QueryAll(withDuplicateColumnKeys(ctx), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := newDeserializeOptions(ctx, exec)
+	afterLoad := opts.afterLoad
+	opts.afterLoad = nil // Run once each parent has all of its children
+	result := make([]T, 0)
+	parents := make(map[string]*aggregateChild)
+	for _, row := range rows {
+		key, err := aggregateKey(row, keyTag, modelType.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		parent, ok := parents[key]
+		if !ok {
+			model, err := deserializeForTypeWithOptions[T](row, opts)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, model)
+			parent = &aggregateChild{index: len(result) - 1}
+			parents[key] = parent
+		}
+
+		if err := aggregateChildren(reflect.ValueOf(result[parent.index]), row, &parent.node, opts); err != nil {
+			return nil, fmt.Errorf("typedb: %s: %w", modelType.Elem().Name(), err)
+		}
+	}
+
+	nodes := make([]*aggregateNode, len(result))
+	for _, parent := range parents {
+		nodes[parent.index] = &parent.node
+	}
+	for i, model := range result {
+		if err := runAggregateAfterLoad(ctx, exec, reflect.ValueOf(model), nodes[i]); err != nil {
+			return nil, fmt.Errorf("typedb: %s: %w", modelType.Elem().Name(), err)
+		}
+		if err := afterLoad(model); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// aggregateChildren appends the children found in row to the slice fields of the struct pointed to by ptr,
+// merging children already recorded in node, and recurses into each child.
+func aggregateChildren(ptr reflect.Value, row map[string]any, node *aggregateNode, opts deserializeOptions) error {
+	structValue := ptr.Elem()
+	var fieldMap map[string]reflect.Value
+
+	for _, field := range nestedStructFields(structValue.Type()) {
+		if !field.slice {
+			continue
+		}
+		if _, ok := row[field.tag]; ok {
+			continue
+		}
+		columns, consumed := nestedColumns(row, field)
+		if len(consumed) == 0 || allNull(columns) {
+			continue
+		}
+
+		keyTag, err := aggregateKeyTag(field.structType)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.tag, err)
+		}
+		key, err := aggregateKey(columns, keyTag, field.structType)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.tag, err)
+		}
+
+		if fieldMap == nil {
+			fieldMap = buildFieldMapFromPtr(ptr, structValue)
+		}
+		sliceValue := fieldMap[field.tag].Elem()
+
+		if node.children == nil {
+			node.children = make(map[string]map[string]*aggregateChild)
+		}
+		byKey := node.children[field.tag]
+		if byKey == nil {
+			byKey = make(map[string]*aggregateChild)
+			node.children[field.tag] = byKey
+		}
+
+		child, ok := byKey[key]
+		if !ok {
+			childPtr := reflect.New(field.structType)
+			if _, _, _, err := deserializeStructFields(columns, childPtr, opts); err != nil {
+				return fmt.Errorf("field %s: %w", field.tag, err)
+			}
+			if field.pointer {
+				sliceValue.Set(reflect.Append(sliceValue, childPtr))
+			} else {
+				sliceValue.Set(reflect.Append(sliceValue, childPtr.Elem()))
+			}
+			child = &aggregateChild{index: sliceValue.Len() - 1}
+			byKey[key] = child
+		}
+
+		childValue := sliceValue.Index(child.index)
+		if !field.pointer {
+			childValue = childValue.Addr()
+		}
+		if err := aggregateChildren(childValue, columns, &child.node, opts); err != nil {
+			return fmt.Errorf("field %s: %w", field.tag, err)
+		}
+	}
+
+	return nil
+}
+
+// runAggregateAfterLoad runs AfterLoad hooks on the children aggregated into the struct pointed to
+// by ptr, depth first, so each child's hook sees its own children.
+func runAggregateAfterLoad(ctx context.Context, exec Executor, ptr reflect.Value, node *aggregateNode) error {
+	if node == nil || len(node.children) == 0 {
+		return nil
+	}
+	fieldMap := buildFieldMapFromPtr(ptr, ptr.Elem())
+	for _, field := range nestedStructFields(ptr.Elem().Type()) {
+		byKey, ok := node.children[field.tag]
+		if !ok {
+			continue
+		}
+		sliceValue := fieldMap[field.tag].Elem()
+		children := make([]*aggregateNode, sliceValue.Len())
+		for _, child := range byKey {
+			children[child.index] = &child.node
+		}
+		for i, childNode := range children {
+			childValue := sliceValue.Index(i)
+			if !field.pointer {
+				childValue = childValue.Addr()
+			}
+			if err := runAggregateAfterLoad(ctx, exec, childValue, childNode); err != nil {
+				return fmt.Errorf("field %s: %w", field.tag, err)
+			}
+			if err := runAfterLoad(ctx, exec, childValue.Interface()); err != nil {
+				return fmt.Errorf("field %s: %w", field.tag, err)
+			}
+		}
+	}
+	return nil
+}
+
+// aggregateKeyTag returns the db tag of the load:"primary" field of t, which identifies rows for aggregation.
+func aggregateKeyTag(t reflect.Type) (string, error) {
+	field, found := findFieldByTagRecursive(t, "load", "primary")
+	if !found {
+		return "", fmt.Errorf("typedb: %s must have a field with load:\"primary\" tag for aggregation", t.Name())
+	}
+	tag := field.Tag.Get("db")
+	if tag == "" || tag == "-" {
+		return "", fmt.Errorf("typedb: %s primary key field %s has no db tag", t.Name(), field.Name)
+	}
+	return tag, nil
+}
+
+// aggregateKey returns a string identifying the row by its primary key column.
+func aggregateKey(row map[string]any, keyTag string, t reflect.Type) (string, error) {
+	value, ok := row[keyTag]
+	if !ok {
+		return "", fmt.Errorf("typedb: %s primary key column %q is missing from the result", t.Name(), keyTag)
+	}
+	if value == nil {
+		return "", fmt.Errorf("typedb: %s primary key column %q is NULL", t.Name(), keyTag)
+	}
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	return fmt.Sprintf("%T:%v", value, value), nil
+}
@@ -0,0 +1,235 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// AggregateTestComment is a grandchild in aggregation tests
+type AggregateTestComment struct {
+	Body string `db:"body"`
+	ID   int64  `db:"id" load:"primary"`
+}
+
+// AggregateTestPost is a child with its own children
+type AggregateTestPost struct {
+	Comments []AggregateTestComment `db:"comments"`
+	Title    string                 `db:"title"`
+	ID       int64                  `db:"id" load:"primary"`
+}
+
+// AggregateTestRole is a child stored by pointer
+type AggregateTestRole struct {
+	Name string `db:"name"`
+	ID   int64  `db:"id" load:"primary"`
+}
+
+// AggregateTestUser is a parent model with child slices
+type AggregateTestUser struct {
+	Model
+	Posts []AggregateTestPost  `db:"posts"`
+	Roles []*AggregateTestRole `db:"roles"`
+	Name  string               `db:"name"`
+	ID    int64                `db:"id" load:"primary"`
+}
+
+func (u *AggregateTestUser) TableName() string {
+	return "users"
+}
+
+func (u *AggregateTestUser) QueryByID() string {
+	return "SELECT id, name FROM users WHERE id = ?"
+}
+
+// AggregateTestNoKey is a test model without a primary key
+type AggregateTestNoKey struct {
+	Model
+	Name string `db:"name"`
+}
+
+func aggregateTestRows(rows ...map[string]any) *MockExecutor {
+	return &MockExecutor{
+		QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+			return rows, nil
+		},
+	}
+}
+
+func TestQueryAggregate_GroupsUnorderedRows(t *testing.T) {
+	ctx := context.Background()
+	mock := aggregateTestRows(
+		map[string]any{"id": int64(1), "name": "Alice", "posts.id": int64(10), "posts.title": "A", "roles.id": int64(100), "roles.name": "admin"},
+		map[string]any{"id": int64(2), "name": "Bob", "posts.id": nil, "posts.title": nil, "roles.id": nil, "roles.name": nil},
+		map[string]any{"id": int64(1), "name": "Alice", "posts.id": int64(11), "posts.title": "B", "roles.id": int64(100), "roles.name": "admin"},
+		map[string]any{"id": int64(1), "name": "Alice", "posts.id": int64(10), "posts.title": "A", "roles.id": int64(101), "roles.name": "editor"},
+	)
+
+	users, err := QueryAggregate[*AggregateTestUser](ctx, mock, "SELECT ...")
+	if err != nil {
+		t.Fatalf("QueryAggregate failed: %v", err)
+	}
+	if len(users) != 2 || users[0].ID != 1 || users[1].ID != 2 {
+		t.Fatalf("Expected users 1 and 2 in first-seen order, got %+v", users)
+	}
+
+	alice := users[0]
+	if want := []AggregateTestPost{{ID: 10, Title: "A"}, {ID: 11, Title: "B"}}; !reflect.DeepEqual(alice.Posts, want) {
+		t.Errorf("Expected de-duplicated posts %+v, got %+v", want, alice.Posts)
+	}
+	if len(alice.Roles) != 2 || alice.Roles[0].Name != "admin" || alice.Roles[1].Name != "editor" {
+		t.Errorf("Unexpected roles %+v", alice.Roles)
+	}
+	if len(users[1].Posts) != 0 || len(users[1].Roles) != 0 {
+		t.Errorf("Expected no children for a LEFT JOIN without matches, got %+v", users[1])
+	}
+}
+
+func TestQueryAggregate_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := QueryAggregate[*AggregateTestUser](ctx, aggregateTestRows(map[string]any{"name": "Alice"}), "SELECT ...")
+	if err == nil || !strings.Contains(err.Error(), `primary key column "id" is missing`) {
+		t.Errorf("Expected missing parent key error, got %v", err)
+	}
+
+	_, err = QueryAggregate[*AggregateTestUser](ctx, aggregateTestRows(map[string]any{"id": int64(1), "posts.title": "A"}), "SELECT ...")
+	if err == nil || !strings.Contains(err.Error(), "AggregateTestPost primary key column") {
+		t.Errorf("Expected missing child key error, got %v", err)
+	}
+
+	_, err = QueryAggregate[*AggregateTestNoKey](ctx, aggregateTestRows(), "SELECT ...")
+	if err == nil || !strings.Contains(err.Error(), `load:"primary"`) {
+		t.Errorf("Expected missing primary field error, got %v", err)
+	}
+}
+
+// AggregateHookComment is a grandchild with an AfterLoad hook
+type AggregateHookComment struct {
+	Body   string `db:"body"`
+	Loaded bool   `db:"-"`
+	ID     int64  `db:"id" load:"primary"`
+}
+
+func (c *AggregateHookComment) AfterLoad(ctx context.Context, exec Executor) error {
+	c.Loaded = true
+	return nil
+}
+
+// AggregateHookPost is a child whose AfterLoad hook reads its aggregated children
+type AggregateHookPost struct {
+	Comments []AggregateHookComment `db:"comments"`
+	Loaded   int                    `db:"-"`
+	ID       int64                  `db:"id" load:"primary"`
+}
+
+func (p *AggregateHookPost) AfterLoad(ctx context.Context, exec Executor) error {
+	for _, comment := range p.Comments {
+		if comment.Loaded {
+			p.Loaded++
+		}
+	}
+	if p.ID == 99 {
+		return errors.New("rejected post")
+	}
+	return nil
+}
+
+// AggregateHookUser is a parent model whose children have AfterLoad hooks
+type AggregateHookUser struct {
+	Model
+	Posts  []*AggregateHookPost `db:"posts"`
+	Loaded int                  `db:"-"`
+	ID     int64                `db:"id" load:"primary"`
+}
+
+func (u *AggregateHookUser) AfterLoad(ctx context.Context, exec Executor) error {
+	for _, post := range u.Posts {
+		u.Loaded += post.Loaded
+	}
+	return nil
+}
+
+func TestQueryAggregate_ChildAfterLoad(t *testing.T) {
+	ctx := context.Background()
+	users, err := QueryAggregate[*AggregateHookUser](ctx, aggregateTestRows(
+		map[string]any{"id": int64(1), "posts.id": int64(10), "posts.comments.id": int64(100), "posts.comments.body": "a"},
+		map[string]any{"id": int64(1), "posts.id": int64(10), "posts.comments.id": int64(101), "posts.comments.body": "b"},
+		map[string]any{"id": int64(1), "posts.id": int64(11), "posts.comments.id": int64(102), "posts.comments.body": "c"},
+	), "SELECT ...")
+	if err != nil {
+		t.Fatalf("QueryAggregate failed: %v", err)
+	}
+	user := users[0]
+	if len(user.Posts) != 2 || user.Posts[0].Loaded != 2 || user.Posts[1].Loaded != 1 || user.Loaded != 3 {
+		t.Errorf("Expected AfterLoad on every child after its own children, got %+v", user)
+	}
+
+	_, err = QueryAggregate[*AggregateHookUser](ctx, aggregateTestRows(map[string]any{"id": int64(1), "posts.id": int64(99)}), "SELECT ...")
+	if err == nil || !strings.Contains(err.Error(), "rejected post") {
+		t.Errorf("Expected child AfterLoad error, got %v", err)
+	}
+}
+
+func TestQueryAggregate_SQLiteMultiLevel(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1), WithColumnPolicy(ColumnPolicyErrorOnUnknown))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER, title TEXT)",
+		"CREATE TABLE comments (id INTEGER PRIMARY KEY, post_id INTEGER, body TEXT)",
+		"INSERT INTO users (id, name) VALUES (1, 'Alice'), (2, 'Bob')",
+		"INSERT INTO posts (id, user_id, title) VALUES (10, 1, 'First'), (11, 1, 'Second')",
+		"INSERT INTO comments (id, post_id, body) VALUES (100, 10, 'Nice'), (101, 10, 'Agreed')",
+	} {
+		if _, err := db.Exec(ctx, stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+
+	users, err := QueryAggregate[*AggregateTestUser](ctx, db, `
+		SELECT u.id, u.name,
+		       p.id AS posts_id, p.title AS posts_title,
+		       c.id AS posts_comments_id, c.body AS "posts.comments.body"
+		FROM users u
+		LEFT JOIN posts p ON p.user_id = u.id
+		LEFT JOIN comments c ON c.post_id = p.id
+		ORDER BY c.id DESC, u.id`)
+	if err != nil {
+		t.Fatalf("QueryAggregate failed: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("Expected 2 users, got %d", len(users))
+	}
+
+	var alice *AggregateTestUser
+	for _, user := range users {
+		if user.ID == 1 {
+			alice = user
+		}
+	}
+	if alice == nil || len(alice.Posts) != 2 {
+		t.Fatalf("Expected Alice with 2 posts, got %+v", alice)
+	}
+	for _, post := range alice.Posts {
+		switch post.ID {
+		case 10:
+			if len(post.Comments) != 2 || post.Comments[0].Body != "Agreed" || post.Comments[1].Body != "Nice" {
+				t.Errorf("Unexpected comments %+v", post.Comments)
+			}
+		case 11:
+			if len(post.Comments) != 0 {
+				t.Errorf("Expected no comments, got %+v", post.Comments)
+			}
+		default:
+			t.Errorf("Unexpected post %+v", post)
+		}
+	}
+}
@@ -0,0 +1,623 @@
+package typedb
+
+import (
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// isCollectionType reports whether t is a slice or map routed through the collection encoder.
+// []byte and named byte slices (e.g., net.IP) are binary values, not collections.
+func isCollectionType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Uint8
+	case reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// usesPostgresArrays reports whether slices are written as native array literals for a driver.
+// MySQL, SQLite, SQL Server and Oracle store slices as JSON arrays.
+func usesPostgresArrays(driverName string) bool {
+	switch strings.ToLower(driverName) {
+	case "mysql", "sqlite3", "sqlserver", "mssql", "oracle":
+		return false
+	default:
+		// Default to PostgreSQL style
+		return true
+	}
+}
+
+// encodeCollection serializes a slice or map field for the driver:
+//   - PostgreSQL: slices as array literals ({1,2,3}, {"a","b"}, nested {{1,2},{3,4}}); maps as JSON objects
+//   - MySQL, SQLite, SQL Server, Oracle: slices as JSON arrays; maps as JSON objects
+func encodeCollection(v reflect.Value, driverName string) (any, error) {
+	if v.Kind() == reflect.Slice && usesPostgresArrays(driverName) {
+		var b strings.Builder
+		if err := writePostgresArray(&b, v); err != nil {
+			return nil, err
+		}
+		return b.String(), nil
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode %v as JSON: %w", v.Type(), err)
+	}
+	return string(data), nil
+}
+
+// writePostgresArray writes a slice as a PostgreSQL array literal.
+func writePostgresArray(b *strings.Builder, v reflect.Value) error {
+	b.WriteByte('{')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if err := writePostgresArrayElement(b, v.Index(i)); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	b.WriteByte('}')
+	return nil
+}
+
+// writePostgresArrayElement writes a single array element. Strings, times and bytes are always
+// quoted, so empty strings, whitespace and the word NULL survive the round trip.
+func writePostgresArrayElement(b *strings.Builder, e reflect.Value) error {
+	for e.Kind() == reflect.Ptr || e.Kind() == reflect.Interface {
+		if e.IsNil() {
+			b.WriteString("NULL")
+			return nil
+		}
+		e = e.Elem()
+	}
+
+	if e.Type() == timeType {
+		t, _ := e.Interface().(time.Time)
+		writePostgresQuoted(b, t.Format(time.RFC3339Nano))
+		return nil
+	}
+
+	switch e.Kind() {
+	case reflect.String:
+		writePostgresQuoted(b, e.String())
+	case reflect.Bool:
+		if e.Bool() {
+			b.WriteByte('t')
+		} else {
+			b.WriteByte('f')
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.WriteString(strconv.FormatInt(e.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b.WriteString(strconv.FormatUint(e.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		b.WriteString(formatPostgresFloat(e.Float(), e.Type().Bits()))
+	case reflect.Slice:
+		if e.Type().Elem().Kind() == reflect.Uint8 {
+			writePostgresQuoted(b, `\x`+hex.EncodeToString(e.Bytes()))
+			return nil
+		}
+		return writePostgresArray(b, e)
+	default:
+		return fmt.Errorf("unsupported PostgreSQL array element type %v", e.Type())
+	}
+	return nil
+}
+
+// writePostgresQuoted writes s as a double-quoted array element, escaping quotes and backslashes.
+func writePostgresQuoted(b *strings.Builder, s string) {
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+}
+
+// formatPostgresFloat formats a float using PostgreSQL's spellings for special values.
+func formatPostgresFloat(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, bits)
+	}
+}
+
+// decodeCollection deserializes array literal or JSON text into a slice or map field.
+// Slices accept PostgreSQL array literals ({...}) and JSON arrays ([...]); maps accept JSON objects.
+// Returns errNotMyType if the field is not a collection or the value is not text.
+func decodeCollection(fieldValuePtr reflect.Value, value any) error {
+	fieldElem := fieldValuePtr.Elem()
+	fieldType := fieldElem.Type()
+	if fieldType.Kind() == reflect.Ptr && isCollectionType(fieldType.Elem()) {
+		ptr := reflect.New(fieldType.Elem())
+		if err := decodeCollection(ptr, value); err != nil {
+			return err
+		}
+		fieldElem.Set(ptr)
+		return nil
+	}
+	if !isCollectionType(fieldType) {
+		return errNotMyType
+	}
+
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return errNotMyType
+	}
+
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		if fieldType.Kind() == reflect.Map {
+			fieldElem.Set(reflect.MakeMap(fieldType))
+		} else {
+			fieldElem.Set(reflect.MakeSlice(fieldType, 0, 0))
+		}
+		return nil
+	}
+
+	if fieldType.Kind() == reflect.Slice && (trimmed[0] != '[' || hasArrayDimensions(trimmed)) {
+		elems, err := parsePostgresArray(trimmed)
+		if err != nil {
+			return err
+		}
+		result, err := buildSliceFromArray(fieldType, elems)
+		if err != nil {
+			return err
+		}
+		fieldElem.Set(result)
+		return nil
+	}
+
+	decoded := reflect.New(fieldType)
+	if err := json.Unmarshal([]byte(trimmed), decoded.Interface()); err != nil {
+		return fmt.Errorf("typedb: cannot decode %v from JSON: %w", fieldType, err)
+	}
+	fieldElem.Set(decoded.Elem())
+	return nil
+}
+
+// hasArrayDimensions reports whether s starts with a PostgreSQL dimension prefix such as "[1:3]=".
+func hasArrayDimensions(s string) bool {
+	eq := strings.IndexByte(s, '=')
+	if eq < 0 {
+		return false
+	}
+	return strings.Trim(s[:eq], "[]:-0123456789") == ""
+}
+
+// parsePostgresArray parses a PostgreSQL array literal into nested []any.
+// Elements are strings, nil for NULL, or []any for nested arrays.
+// An optional dimension prefix (e.g., "[0:2]={1,2,3}") is ignored.
+func parsePostgresArray(s string) ([]any, error) {
+	if hasArrayDimensions(s) {
+		s = s[strings.IndexByte(s, '=')+1:]
+	}
+
+	p := &postgresArrayParser{input: s}
+	elems, err := p.parseArray()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("typedb: invalid array literal %q: trailing characters at %d", s, p.pos)
+	}
+	return elems, nil
+}
+
+// postgresArrayParser is a recursive descent parser for PostgreSQL array literals.
+type postgresArrayParser struct {
+	input string
+	pos   int
+}
+
+func (p *postgresArrayParser) skipSpaces() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\n' || p.input[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func (p *postgresArrayParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("typedb: invalid array literal %q: %s at %d", p.input, fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *postgresArrayParser) parseArray() ([]any, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) || p.input[p.pos] != '{' {
+		return nil, p.errorf("expected '{'")
+	}
+	p.pos++
+
+	elems := []any{}
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == '}' {
+		p.pos++
+		return elems, nil
+	}
+
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) {
+			return nil, p.errorf("unexpected end")
+		}
+
+		switch p.input[p.pos] {
+		case '{':
+			nested, err := p.parseArray()
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, nested)
+		case '"':
+			s, err := p.parseQuoted()
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, s)
+		default:
+			s := p.parseUnquoted()
+			if strings.EqualFold(s, "NULL") {
+				elems = append(elems, nil)
+			} else {
+				elems = append(elems, s)
+			}
+		}
+
+		p.skipSpaces()
+		if p.pos >= len(p.input) {
+			return nil, p.errorf("unexpected end")
+		}
+		switch p.input[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return elems, nil
+		default:
+			return nil, p.errorf("expected ',' or '}'")
+		}
+	}
+}
+
+func (p *postgresArrayParser) parseQuoted() (string, error) {
+	p.pos++ // opening quote
+	var b strings.Builder
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		switch c {
+		case '\\':
+			p.pos++
+			if p.pos >= len(p.input) {
+				return "", p.errorf("unterminated escape")
+			}
+			b.WriteByte(p.input[p.pos])
+		case '"':
+			p.pos++
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+		p.pos++
+	}
+	return "", p.errorf("unterminated quoted element")
+}
+
+func (p *postgresArrayParser) parseUnquoted() string {
+	var b strings.Builder
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c == ',' || c == '}' {
+			break
+		}
+		if c == '\\' && p.pos+1 < len(p.input) {
+			p.pos++
+			c = p.input[p.pos]
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// buildSliceFromArray converts parsed array elements into a slice of type t.
+func buildSliceFromArray(t reflect.Type, elems []any) (reflect.Value, error) {
+	result := reflect.MakeSlice(t, len(elems), len(elems))
+	elemType := t.Elem()
+
+	for i, elem := range elems {
+		target := result.Index(i)
+		switch v := elem.(type) {
+		case nil:
+			// NULL leaves the zero value (nil for pointer elements)
+		case []any:
+			nestedType := elemType
+			if nestedType.Kind() == reflect.Ptr {
+				nestedType = nestedType.Elem()
+			}
+			if nestedType.Kind() != reflect.Slice {
+				return reflect.Value{}, fmt.Errorf("element %d: nested array cannot be decoded into %v", i, elemType)
+			}
+			nested, err := buildSliceFromArray(nestedType, v)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+			}
+			if elemType.Kind() == reflect.Ptr {
+				ptr := reflect.New(nestedType)
+				ptr.Elem().Set(nested)
+				nested = ptr
+			}
+			target.Set(nested)
+		case string:
+			if err := decodeArrayElement(target.Addr(), v); err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// decodeArrayElement converts the text of an array element into the element pointed to by targetPtr.
+func decodeArrayElement(targetPtr reflect.Value, s string) error {
+	target := targetPtr.Elem()
+	if target.Kind() == reflect.Ptr {
+		ptr := reflect.New(target.Type().Elem())
+		if err := decodeArrayElement(ptr, s); err != nil {
+			return err
+		}
+		target.Set(ptr)
+		return nil
+	}
+
+	if target.Type() == timeType {
+		t, err := parseTime(s)
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(s)
+	case reflect.Bool:
+		b, err := parseBoolString(s)
+		if err != nil {
+			return err
+		}
+		target.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := parsePostgresFloat(s, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(f)
+	case reflect.Slice:
+		if target.Type().Elem().Kind() == reflect.Uint8 && strings.HasPrefix(s, `\x`) {
+			data, err := hex.DecodeString(s[2:])
+			if err != nil {
+				return err
+			}
+			target.SetBytes(data)
+			return nil
+		}
+		return deserializeToFieldValue(targetPtr, s)
+	default:
+		return deserializeToFieldValue(targetPtr, s)
+	}
+	return nil
+}
+
+// parsePostgresFloat parses a float, accepting PostgreSQL's spellings for special values.
+func parsePostgresFloat(s string, bits int) (float64, error) {
+	switch strings.ToLower(s) {
+	case "nan":
+		return math.NaN(), nil
+	case "infinity":
+		return math.Inf(1), nil
+	case "-infinity":
+		return math.Inf(-1), nil
+	default:
+		return strconv.ParseFloat(s, bits)
+	}
+}
+
+// collectionFieldError reports whether a collection type can be encoded for a driver.
+// An empty driverName checks only driver-independent (JSON) support.
+func collectionFieldError(t reflect.Type, driverName string) error {
+	if t.Kind() == reflect.Slice && driverName != "" && usesPostgresArrays(driverName) {
+		return postgresArrayElementError(t.Elem())
+	}
+	return jsonEncodableError(t, map[reflect.Type]bool{})
+}
+
+// postgresArrayElementError reports element types that cannot appear in a PostgreSQL array literal.
+func postgresArrayElementError(t reflect.Type) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Interface:
+		return nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		return postgresArrayElementError(t.Elem())
+	default:
+		return fmt.Errorf("PostgreSQL arrays cannot hold %v elements", t)
+	}
+}
+
+// jsonEncodableError reports types that encoding/json cannot marshal.
+func jsonEncodableError(t reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return fmt.Errorf("%v cannot be encoded as JSON", t)
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return jsonEncodableError(t.Elem(), seen)
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			if !t.Key().Implements(textMarshalerType) {
+				return fmt.Errorf("map key type %v cannot be encoded as JSON", t.Key())
+			}
+		}
+		return jsonEncodableError(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("json") == "-" {
+				continue
+			}
+			if err := jsonEncodableError(field.Type, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// validateCollectionFields checks that slice and map fields written by the collection encoder
+// can be encoded for a driver. Fields with dbType:"json", a codec or a driver.Valuer are skipped.
+// An empty driverName checks only driver-independent (JSON) support.
+func validateCollectionFields(t reflect.Type, driverName string, dbCodecs *codecRegistry) []string {
+	var errors []string
+
+	var check func(reflect.Type)
+	check = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					check(embeddedType)
+					continue
+				}
+			}
+			dbTag := field.Tag.Get("db")
+			if dbTag == "" || dbTag == "-" || dbTag == collectColumnsTag || isJSONField(field) || isNestedStructField(field, dbCodecs) {
+				continue
+			}
+
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if !isCollectionType(fieldType) || hasCustomEncoding(fieldType, dbCodecs) {
+				continue
+			}
+			if err := collectionFieldError(fieldType, driverName); err != nil {
+				if driverName == "" {
+					errors = append(errors, fmt.Sprintf("field %s: %v", field.Name, err))
+				} else {
+					errors = append(errors, fmt.Sprintf("field %s: %v (driver %s); use dbType:\"json\", a codec or driver.Valuer", field.Name, err, driverName))
+				}
+			}
+		}
+	}
+
+	check(t)
+	return errors
+}
+
+// hasCustomEncoding reports whether values of t are encoded by a codec or driver.Valuer
+// rather than the collection encoder.
+func hasCustomEncoding(t reflect.Type, dbCodecs *codecRegistry) bool {
+	if _, ok := lookupCodec(dbCodecs, t); ok {
+		return true
+	}
+	if _, ok := builtinCodec(t); ok {
+		return true
+	}
+	return t.Implements(valuerType) || reflect.PointerTo(t).Implements(valuerType)
+}
+
+// validateRegisteredCollections checks every registered model's collection fields against a driver.
+// Returns ValidationErrors containing all failures grouped by model.
+func validateRegisteredCollections(driverName string, dbCodecs *codecRegistry) error {
+	var validationErrors []*ValidationError
+	for _, modelType := range GetRegisteredModels() {
+		if errors := validateCollectionFields(modelType, driverName, dbCodecs); len(errors) > 0 {
+			validationErrors = append(validationErrors, &ValidationError{
+				ModelName: modelType.Name(),
+				Errors:    errors,
+			})
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return &ValidationErrors{
+			Errors: validationErrors,
+		}
+	}
+	return nil
+}
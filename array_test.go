@@ -0,0 +1,332 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ArrayTestPoint is a struct element, encodable as JSON but not as a PostgreSQL array element
+type ArrayTestPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// ArrayTestUser is a test model with slice and map columns
+type ArrayTestUser struct {
+	Model
+	Attrs   map[string]string `db:"attrs"`
+	Nick    *[]string         `db:"nick"`
+	Name    string            `db:"name"`
+	Tags    []string          `db:"tags"`
+	Scores  []float64         `db:"scores"`
+	Flags   []bool            `db:"flags"`
+	Matrix  [][]int           `db:"matrix"`
+	Times   []time.Time       `db:"times"`
+	Ratings []*int            `db:"ratings"`
+	ID      int64             `db:"id" load:"primary"`
+}
+
+func (u *ArrayTestUser) TableName() string {
+	return "users"
+}
+
+func (u *ArrayTestUser) QueryByID() string {
+	return "SELECT id, name, tags, scores, flags, matrix, times, ratings, attrs, nick FROM users WHERE id = ?"
+}
+
+// ArrayTestPoints stores struct elements, which PostgreSQL arrays cannot hold
+type ArrayTestPoints struct {
+	Model
+	Points []ArrayTestPoint `db:"points"`
+	ID     int64            `db:"id" load:"primary"`
+}
+
+func (p *ArrayTestPoints) TableName() string {
+	return "points"
+}
+
+func (p *ArrayTestPoints) QueryByID() string {
+	return "SELECT id, points FROM points WHERE id = ?"
+}
+
+// ArrayTestBadElem has a slice of a type that cannot be encoded at all
+type ArrayTestBadElem struct {
+	Model
+	Callbacks []func() `db:"callbacks"`
+	ID        int64    `db:"id"`
+}
+
+func TestEncodeCollection_Postgres(t *testing.T) {
+	one := 1
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+	tests := []struct {
+		value any
+		name  string
+		want  string
+	}{
+		{name: "ints", value: []int{1, -2, 3}, want: "{1,-2,3}"},
+		{name: "empty", value: []int{}, want: "{}"},
+		{name: "strings", value: []string{"a", "", "NULL", `say "hi"`, `back\slash`, "x,y", "{z}"}, want: `{"a","","NULL","say \"hi\"","back\\slash","x,y","{z}"}`},
+		{name: "floats", value: []float64{1.5, math.Inf(1), math.Inf(-1), math.NaN()}, want: "{1.5,Infinity,-Infinity,NaN}"},
+		{name: "bools", value: []bool{true, false}, want: "{t,f}"},
+		{name: "times", value: []time.Time{ts}, want: `{"2024-01-02T03:04:05.0000006Z"}`},
+		{name: "nested", value: [][]int{{1, 2}, {3, 4}}, want: "{{1,2},{3,4}}"},
+		{name: "null elements", value: []*int{&one, nil}, want: "{1,NULL}"},
+		{name: "bytes", value: [][]byte{{0xde, 0xad}}, want: `{"\\xdead"}`},
+		{name: "map", value: map[string]int{"a": 1}, want: `{"a":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeCollection(reflect.ValueOf(tt.value), "postgres")
+			if err != nil {
+				t.Fatalf("encodeCollection failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %s, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEncodeCollection_JSON(t *testing.T) {
+	for _, driverName := range []string{"mysql", "MySQL", "sqlite3", "sqlserver", "oracle"} {
+		got, err := encodeCollection(reflect.ValueOf([]string{"a", `b"c`}), driverName)
+		if err != nil {
+			t.Fatalf("%s: encodeCollection failed: %v", driverName, err)
+		}
+		if got != `["a","b\"c"]` {
+			t.Errorf("%s: Expected JSON array, got %v", driverName, got)
+		}
+	}
+}
+
+func TestUsesPostgresArrays(t *testing.T) {
+	for _, driverName := range []string{"postgres", "Postgres", "pgx", ""} {
+		if !usesPostgresArrays(driverName) {
+			t.Errorf("%q: expected PostgreSQL arrays", driverName)
+		}
+	}
+	if usesPostgresArrays("MSSQL") {
+		t.Error("Expected JSON arrays for MSSQL")
+	}
+}
+
+func TestDecodeCollection_PostgresLiterals(t *testing.T) {
+	user, err := deserializeForType[*ArrayTestUser](map[string]any{
+		"tags":    `{a,"b c","","NULL","say \"hi\"","back\\slash",NULL}`,
+		"scores":  "{1.5,Infinity,-Infinity}",
+		"flags":   "{t,false,TRUE}",
+		"matrix":  "{{1,2},{3,4}}",
+		"times":   `{"2024-01-02 03:04:05.5+00","2024-01-02T03:04:05Z"}`,
+		"ratings": "[1:2]={7,NULL}",
+		"nick":    []byte("{bob}"),
+	})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+
+	if want := []string{"a", "b c", "", "NULL", `say "hi"`, `back\slash`, ""}; !reflect.DeepEqual(user.Tags, want) {
+		t.Errorf("Expected Tags %q, got %q", want, user.Tags)
+	}
+	if len(user.Scores) != 3 || user.Scores[0] != 1.5 || !math.IsInf(user.Scores[1], 1) || !math.IsInf(user.Scores[2], -1) {
+		t.Errorf("Unexpected Scores %v", user.Scores)
+	}
+	if !reflect.DeepEqual(user.Flags, []bool{true, false, true}) {
+		t.Errorf("Unexpected Flags %v", user.Flags)
+	}
+	if !reflect.DeepEqual(user.Matrix, [][]int{{1, 2}, {3, 4}}) {
+		t.Errorf("Unexpected Matrix %v", user.Matrix)
+	}
+	wantTime := time.Date(2024, 1, 2, 3, 4, 5, 500000000, time.UTC)
+	if len(user.Times) != 2 || !user.Times[0].Equal(wantTime) || !user.Times[1].Equal(wantTime.Truncate(time.Second)) {
+		t.Errorf("Unexpected Times %v", user.Times)
+	}
+	if len(user.Ratings) != 2 || user.Ratings[0] == nil || *user.Ratings[0] != 7 || user.Ratings[1] != nil {
+		t.Errorf("Unexpected Ratings %v", user.Ratings)
+	}
+	if user.Nick == nil || !reflect.DeepEqual(*user.Nick, []string{"bob"}) {
+		t.Errorf("Unexpected Nick %v", user.Nick)
+	}
+}
+
+func TestDecodeCollection_RoundTrip(t *testing.T) {
+	one := 1
+	values := []any{
+		[]string{"a", "", "NULL", `q"uote`, `b\s`, " padded ", "{}"},
+		[]float64{0.1, -2.5e-10, math.MaxFloat64},
+		[][]string{{"a", "b"}, {"c,d", "e"}},
+		[]*int{&one, nil},
+	}
+
+	for _, value := range values {
+		encoded, err := encodeCollection(reflect.ValueOf(value), "postgres")
+		if err != nil {
+			t.Fatalf("encodeCollection(%v) failed: %v", value, err)
+		}
+		decoded := reflect.New(reflect.TypeOf(value))
+		if err := decodeCollection(decoded, encoded); err != nil {
+			t.Fatalf("decodeCollection(%v) failed: %v", encoded, err)
+		}
+		if !reflect.DeepEqual(decoded.Elem().Interface(), value) {
+			t.Errorf("Round trip of %v via %v gave %v", value, encoded, decoded.Elem().Interface())
+		}
+	}
+}
+
+func TestDecodeCollection_JSON(t *testing.T) {
+	user, err := deserializeForType[*ArrayTestUser](map[string]any{
+		"tags":   `["a","b"]`,
+		"matrix": []byte(`[[1],[2,3]]`),
+		"attrs":  `{"k":"v"}`,
+	})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if !reflect.DeepEqual(user.Tags, []string{"a", "b"}) || !reflect.DeepEqual(user.Matrix, [][]int{{1}, {2, 3}}) {
+		t.Errorf("Unexpected slices %v %v", user.Tags, user.Matrix)
+	}
+	if !reflect.DeepEqual(user.Attrs, map[string]string{"k": "v"}) {
+		t.Errorf("Unexpected Attrs %v", user.Attrs)
+	}
+}
+
+func TestDecodeCollection_Invalid(t *testing.T) {
+	for _, value := range []string{"{1,2", "{1,x}", `{"a}`, "{1}junk", "[1,"} {
+		if _, err := deserializeForType[*ArrayTestUser](map[string]any{"matrix": "{{1}}", "scores": value}); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+}
+
+func TestSerializeCollections_ByDriver(t *testing.T) {
+	user := &ArrayTestUser{Name: "Alice", Tags: []string{"a", "b"}, Attrs: map[string]string{"k": "v"}}
+
+	tests := []struct {
+		driverName string
+		wantTags   string
+	}{
+		{driverName: "postgres", wantTags: `{"a","b"}`},
+		{driverName: "mysql", wantTags: `["a","b"]`},
+		{driverName: "sqlite3", wantTags: `["a","b"]`},
+		{driverName: "sqlserver", wantTags: `["a","b"]`},
+	}
+	for _, tt := range tests {
+		opts := serializeOptions{driverName: tt.driverName}
+		columns, values, _, err := serializeModelFieldsWithOptions(user, "ID", opts)
+		if err != nil {
+			t.Fatalf("%s: serialize failed: %v", tt.driverName, err)
+		}
+		got := make(map[string]any)
+		for i, column := range columns {
+			got[column] = values[i]
+		}
+		if got["tags"] != tt.wantTags {
+			t.Errorf("%s: Expected tags %s, got %v", tt.driverName, tt.wantTags, got["tags"])
+		}
+		if got["attrs"] != `{"k":"v"}` {
+			t.Errorf("%s: Expected attrs as JSON object, got %v", tt.driverName, got["attrs"])
+		}
+		if _, ok := got["scores"]; ok {
+			t.Errorf("%s: Expected nil slice to be omitted", tt.driverName)
+		}
+	}
+}
+
+func TestCollections_SQLiteRoundTrip(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, tags TEXT, scores TEXT, flags TEXT, matrix TEXT, times TEXT, ratings TEXT, attrs TEXT, nick TEXT)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	two := 2
+	user := &ArrayTestUser{
+		Name:    "Alice",
+		Tags:    []string{"a", `b"c`},
+		Scores:  []float64{1.25, 2},
+		Flags:   []bool{true, false},
+		Matrix:  [][]int{{1, 2}, {3}},
+		Times:   []time.Time{time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)},
+		Ratings: []*int{&two, nil},
+		Attrs:   map[string]string{"k": "v"},
+	}
+	if err := Insert(ctx, db, user); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	var stored string
+	if err := db.GetInto(ctx, "SELECT tags FROM users WHERE id = ?", []any{user.ID}, &stored); err != nil {
+		t.Fatalf("GetInto failed: %v", err)
+	}
+	if stored != `["a","b\"c"]` {
+		t.Errorf("Expected JSON array in SQLite, got %s", stored)
+	}
+
+	loaded := &ArrayTestUser{ID: user.ID}
+	if err := Load(ctx, db, loaded); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Tags, user.Tags) || !reflect.DeepEqual(loaded.Scores, user.Scores) ||
+		!reflect.DeepEqual(loaded.Flags, user.Flags) || !reflect.DeepEqual(loaded.Matrix, user.Matrix) ||
+		!reflect.DeepEqual(loaded.Attrs, user.Attrs) || !loaded.Times[0].Equal(user.Times[0]) {
+		t.Errorf("Round trip mismatch: %+v", loaded)
+	}
+	if len(loaded.Ratings) != 2 || *loaded.Ratings[0] != 2 || loaded.Ratings[1] != nil {
+		t.Errorf("Unexpected Ratings %v", loaded.Ratings)
+	}
+	if loaded.Nick != nil {
+		t.Errorf("Expected NULL nick, got %v", loaded.Nick)
+	}
+}
+
+func TestValidateModel_CollectionFields(t *testing.T) {
+	if err := ValidateModel(&ArrayTestUser{}); err != nil {
+		t.Errorf("Expected valid model, got %v", err)
+	}
+	if err := ValidateModel(&ArrayTestPoints{}); err != nil {
+		t.Errorf("Expected struct elements to be valid for JSON, got %v", err)
+	}
+
+	err := ValidateModel(&ArrayTestBadElem{})
+	if err == nil || !strings.Contains(err.Error(), "field Callbacks") {
+		t.Errorf("Expected unencodable element error, got %v", err)
+	}
+}
+
+func TestOpen_RejectsUnsupportedCollections(t *testing.T) {
+	withRegisteredModels(t, reflect.TypeOf(ArrayTestUser{}), reflect.TypeOf(ArrayTestPoints{}))
+
+	if errs := validateRegisteredCollections("sqlite3", nil); errs != nil {
+		t.Errorf("Expected struct elements to be accepted as JSON on sqlite3, got %v", errs)
+	}
+
+	err := validateRegisteredCollections("postgres", nil)
+	var validationErrors *ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "ArrayTestPoints") || !strings.Contains(err.Error(), "field Points") || strings.Contains(err.Error(), "ArrayTestUser") {
+		t.Errorf("Expected only Points to be rejected, got %v", err)
+	}
+
+	// A codec for the slice type takes over encoding
+	codecs := newCodecRegistry()
+	codecs.set(newCodec(
+		func(v any) ([]ArrayTestPoint, error) { return nil, nil },
+		func(p []ArrayTestPoint) (any, error) { return "", nil },
+	))
+	if err := validateRegisteredCollections("postgres", codecs); err != nil {
+		t.Errorf("Expected codec to satisfy validation, got %v", err)
+	}
+}
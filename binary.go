@@ -0,0 +1,107 @@
+package typedb
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// binaryDatabaseTypes lists column type names (as reported by ColumnType.DatabaseTypeName)
+// whose values are kept as []byte when scanning rows. All other []byte values become strings.
+var binaryDatabaseTypes = map[string]bool{
+	"BLOB":       true, // SQLite, MySQL, Oracle
+	"TINYBLOB":   true, // MySQL
+	"MEDIUMBLOB": true, // MySQL
+	"LONGBLOB":   true, // MySQL
+	"BINARY":     true, // MySQL, SQL Server
+	"VARBINARY":  true, // MySQL, SQL Server
+	"BYTEA":      true, // PostgreSQL
+	"IMAGE":      true, // SQL Server
+	"RAW":        true, // Oracle
+	"LONG RAW":   true, // Oracle
+}
+
+// binaryColumns reports, for each result column, whether it has a binary database type.
+// Returns nil if the driver does not provide column types, treating every column as text.
+func binaryColumns(rows *sql.Rows) []bool {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil
+	}
+
+	binary := make([]bool, len(columnTypes))
+	for i, columnType := range columnTypes {
+		binary[i] = isBinaryDatabaseType(columnType.DatabaseTypeName())
+	}
+	return binary
+}
+
+// isBinaryDatabaseType reports whether a database type name denotes binary data.
+// Length suffixes (e.g., "VARBINARY(16)") are ignored.
+func isBinaryDatabaseType(name string) bool {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+	return binaryDatabaseTypes[name]
+}
+
+// decodeBinary deserializes binary or text values into byte slice and byte array fields
+// (including named types such as json.RawMessage and pointers to them) without string conversion.
+// The field receives its own copy of the bytes. Returns errNotMyType for other fields and values.
+func decodeBinary(fieldValuePtr reflect.Value, value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errNotMyType
+	}
+
+	fieldElem := fieldValuePtr.Elem()
+	fieldType := fieldElem.Type()
+
+	switch {
+	case fieldType.Kind() == reflect.Ptr && isByteSequence(fieldType.Elem()):
+		ptr := reflect.New(fieldType.Elem())
+		if err := decodeBinary(ptr, value); err != nil {
+			return err
+		}
+		fieldElem.Set(ptr)
+		return nil
+	case fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Uint8:
+		copied := reflect.MakeSlice(fieldType, len(data), len(data))
+		reflect.Copy(copied, reflect.ValueOf(data))
+		fieldElem.Set(copied)
+		return nil
+	case fieldType.Kind() == reflect.Array && fieldType.Elem().Kind() == reflect.Uint8:
+		if len(data) != fieldType.Len() {
+			return fmt.Errorf("typedb: cannot deserialize %d bytes into %v", len(data), fieldType)
+		}
+		array := reflect.New(fieldType).Elem()
+		reflect.Copy(array, reflect.ValueOf(data))
+		fieldElem.Set(array)
+		return nil
+	default:
+		return errNotMyType
+	}
+}
+
+// isByteSequence reports whether t is a byte slice or byte array type.
+func isByteSequence(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
+
+// encodeByteArray converts a byte array (e.g., [32]byte) to a []byte copy, which drivers accept
+// for binary columns. ok is false for other values.
+func encodeByteArray(v reflect.Value) (value any, ok bool) {
+	if v.Kind() != reflect.Array || v.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	data := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(data), v)
+	return data, true
+}
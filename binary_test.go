@@ -0,0 +1,163 @@
+package typedb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// BinaryTestFile is a test model with binary columns
+type BinaryTestFile struct {
+	Model
+	Thumbnail *[]byte         `db:"thumbnail"`
+	Meta      json.RawMessage `db:"meta"`
+	Name      string          `db:"name"`
+	Data      []byte          `db:"data"`
+	Checksum  [32]byte        `db:"checksum"`
+	ID        int64           `db:"id" load:"primary"`
+}
+
+func (f *BinaryTestFile) TableName() string {
+	return "files"
+}
+
+func (f *BinaryTestFile) QueryByID() string {
+	return "SELECT id, name, data, checksum, thumbnail, meta FROM files WHERE id = ?"
+}
+
+// binaryTestPayload contains bytes that are not valid UTF-8, including NUL
+var binaryTestPayload = []byte{0x00, 0xff, 0xfe, 0x80, 'a', 0x00, 0xc3, 0x28}
+
+func TestIsBinaryDatabaseType(t *testing.T) {
+	for _, name := range []string{"BLOB", "blob", "BYTEA", "VARBINARY(16)", "LONG RAW", "image"} {
+		if !isBinaryDatabaseType(name) {
+			t.Errorf("Expected %q to be binary", name)
+		}
+	}
+	for _, name := range []string{"", "TEXT", "VARCHAR(255)", "JSON", "INTEGER", "CLOB"} {
+		if isBinaryDatabaseType(name) {
+			t.Errorf("Expected %q to be text", name)
+		}
+	}
+}
+
+func TestDecodeBinary(t *testing.T) {
+	checksum := bytes.Repeat([]byte{0xab}, 32)
+	source := append([]byte(nil), binaryTestPayload...)
+
+	file, err := deserializeForType[*BinaryTestFile](map[string]any{
+		"data":      source,
+		"checksum":  checksum,
+		"thumbnail": string(binaryTestPayload),
+		"meta":      `{"a":1}`,
+	})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if !bytes.Equal(file.Data, binaryTestPayload) || !bytes.Equal(file.Checksum[:], checksum) {
+		t.Errorf("Unexpected bytes %x %x", file.Data, file.Checksum)
+	}
+	if file.Thumbnail == nil || !bytes.Equal(*file.Thumbnail, binaryTestPayload) {
+		t.Errorf("Unexpected thumbnail %v", file.Thumbnail)
+	}
+	if string(file.Meta) != `{"a":1}` {
+		t.Errorf("Unexpected meta %s", file.Meta)
+	}
+
+	// The field owns its bytes
+	source[0] = 0x42
+	if file.Data[0] != 0x00 {
+		t.Error("Expected field to hold a copy of the column bytes")
+	}
+
+	_, err = deserializeForType[*BinaryTestFile](map[string]any{"checksum": []byte{1, 2, 3}})
+	if err == nil || !strings.Contains(err.Error(), "cannot deserialize 3 bytes into [32]uint8") {
+		t.Errorf("Expected length error, got %v", err)
+	}
+}
+
+func TestSerializeByteArray(t *testing.T) {
+	file := &BinaryTestFile{Checksum: [32]byte{1, 2, 3}}
+	columns, values, _, err := serializeModelFields(file, "ID")
+	if err != nil {
+		t.Fatalf("serializeModelFields failed: %v", err)
+	}
+	if !reflect.DeepEqual(columns, []string{"checksum"}) {
+		t.Fatalf("Expected checksum column, got %v", columns)
+	}
+	if data, ok := values[0].([]byte); !ok || len(data) != 32 || data[2] != 3 {
+		t.Errorf("Expected 32-byte slice, got %#v", values[0])
+	}
+}
+
+func TestByteArray_SixteenBytesIsBinary(t *testing.T) {
+	digest := [16]byte{0xd4, 0x1d, 0x8c, 0xd9, 15: 0x7e}
+	value, err := serializeFieldValue(reflect.ValueOf(digest), serializeOptions{})
+	if data, ok := value.([]byte); err != nil || !ok || !bytes.Equal(data, digest[:]) {
+		t.Errorf("Expected [16]byte to be written as 16 raw bytes, got %#v, %v", value, err)
+	}
+	if _, ok := builtinCodec(reflect.TypeOf(digest)); ok {
+		t.Error("Expected no built-in codec for an unnamed [16]byte")
+	}
+	if _, ok := builtinCodec(reflect.TypeOf(CodecTestUUID{})); !ok {
+		t.Error("Expected the UUID codec for a named UUID type")
+	}
+}
+
+func TestBinary_SQLiteBlobRoundTrip(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE files (id INTEGER PRIMARY KEY, name TEXT, data BLOB, checksum BLOB, thumbnail BLOB, meta TEXT)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	thumbnail := []byte{0xff, 0xd8, 0xff, 0x00}
+	file := &BinaryTestFile{
+		Name:      "image.bin",
+		Data:      binaryTestPayload,
+		Checksum:  [32]byte{0xde, 0xad, 0xbe, 0xef, 31: 0x01},
+		Thumbnail: &thumbnail,
+		Meta:      json.RawMessage(`{"w":1}`),
+	}
+	if err := Insert(ctx, db, file); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	row, err := db.QueryRowMap(ctx, "SELECT name, data FROM files WHERE id = ?", file.ID)
+	if err != nil {
+		t.Fatalf("QueryRowMap failed: %v", err)
+	}
+	if _, ok := row["name"].(string); !ok {
+		t.Errorf("Expected TEXT column as string, got %T", row["name"])
+	}
+	if data, ok := row["data"].([]byte); !ok || !bytes.Equal(data, binaryTestPayload) {
+		t.Errorf("Expected BLOB column as []byte, got %T %v", row["data"], row["data"])
+	}
+
+	loaded := &BinaryTestFile{ID: file.ID}
+	if err := Load(ctx, db, loaded); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !bytes.Equal(loaded.Data, binaryTestPayload) || loaded.Checksum != file.Checksum {
+		t.Errorf("Binary round trip mismatch: %x %x", loaded.Data, loaded.Checksum)
+	}
+	if loaded.Thumbnail == nil || !bytes.Equal(*loaded.Thumbnail, thumbnail) || string(loaded.Meta) != `{"w":1}` {
+		t.Errorf("Unexpected thumbnail/meta %v %s", loaded.Thumbnail, loaded.Meta)
+	}
+
+	files, err := QueryAll[*BinaryTestFile](ctx, db, "SELECT id, data FROM files")
+	if err != nil {
+		t.Fatalf("QueryAll failed: %v", err)
+	}
+	if len(files) != 1 || !bytes.Equal(files[0].Data, binaryTestPayload) {
+		t.Errorf("Unexpected QueryAll result %+v", files)
+	}
+}
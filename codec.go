@@ -0,0 +1,350 @@
+package typedb
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// codec converts between database values and a Go type.
+// decode receives non-NULL column values; encode receives the field value.
+type codec struct {
+	decode func(value any) (reflect.Value, error)
+	encode func(value reflect.Value) (any, error)
+}
+
+// codecRegistry maps Go types to codecs. Safe for concurrent use.
+type codecRegistry struct {
+	codecs map[reflect.Type]codec
+	mu     sync.RWMutex
+}
+
+// globalCodecs holds codecs registered with RegisterCodec.
+var globalCodecs = newCodecRegistry()
+
+func newCodecRegistry() *codecRegistry {
+	return &codecRegistry{codecs: make(map[reflect.Type]codec)}
+}
+
+// set registers c for t, replacing any existing codec.
+func (r *codecRegistry) set(t reflect.Type, c codec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.codecs[t] = c
+}
+
+// get returns the codec registered for t.
+func (r *codecRegistry) get(t reflect.Type) (codec, bool) {
+	if r == nil {
+		return codec{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.codecs[t]
+	return c, ok
+}
+
+// newCodec wraps typed decode/encode functions.
+func newCodec[T any](decode func(any) (T, error), encode func(T) (any, error)) (reflect.Type, codec) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	return t, codec{
+		decode: func(value any) (reflect.Value, error) {
+			decoded, err := decode(value)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(&decoded).Elem(), nil
+		},
+		encode: func(value reflect.Value) (any, error) {
+			typed, ok := value.Interface().(T)
+			if !ok {
+				return nil, fmt.Errorf("typedb: codec for %v received %v", t, value.Type())
+			}
+			return encode(typed)
+		},
+	}
+}
+
+// RegisterCodec registers conversion functions for type T, used by all DBs.
+// The codec is consulted before built-in handling, sql.Scanner and driver.Valuer:
+//   - decode converts a non-NULL column value into T when deserializing T and *T fields
+//   - encode converts a T (or non-nil *T) field into the value passed to the driver by Insert and Update
+//
+// NULL columns never reach decode; they reset the field as for any other type.
+// Codecs registered on a DB with WithCodec or RegisterDBCodec take precedence.
+// Registering a codec for a type that already has one replaces it.
+//
+// Example:
+//
+//	typedb.RegisterCodec(
+//	    func(v any) (Status, error) { return ParseStatus(fmt.Sprint(v)) },
+//	    func(s Status) (any, error) { return s.String(), nil },
+//	)
+func RegisterCodec[T any](decode func(any) (T, error), encode func(T) (any, error)) {
+	globalCodecs.set(newCodec(decode, encode))
+}
+
+// RegisterDBCodec registers conversion functions for type T on a single DB and its transactions.
+// Takes precedence over codecs registered with RegisterCodec. See RegisterCodec for details.
+// Safe to call while the DB is in use; transactions already begun see the codec too.
+// Open validates collection fields before RegisterDBCodec can run, so pass codecs that
+// collection fields depend on with WithCodec instead.
+func RegisterDBCodec[T any](db *DB, decode func(any) (T, error), encode func(T) (any, error)) {
+	db.codecs.set(newCodec(decode, encode))
+}
+
+// WithCodec registers conversion functions for type T on the opened DB.
+// Equivalent to calling RegisterDBCodec after Open.
+func WithCodec[T any](decode func(any) (T, error), encode func(T) (any, error)) Option {
+	return func(cfg *Config) {
+		if cfg.codecs == nil {
+			cfg.codecs = newCodecRegistry()
+		}
+		cfg.codecs.set(newCodec(decode, encode))
+	}
+}
+
+// getExecutorCodecs extracts the DB-level codec registry from an Executor.
+func getExecutorCodecs(exec Executor) *codecRegistry {
+	switch e := exec.(type) {
+	case *DB:
+		return e.codecs
+	case *Tx:
+		return e.codecs
+	default:
+		return nil
+	}
+}
+
+// lookupCodec finds a registered codec for t, checking the DB registry before the global one.
+func lookupCodec(dbCodecs *codecRegistry, t reflect.Type) (codec, bool) {
+	if c, ok := dbCodecs.get(t); ok {
+		return c, true
+	}
+	return globalCodecs.get(t)
+}
+
+// decodeWithRegisteredCodec deserializes value into the field using a registered codec for
+// the field type, or for the element type of a pointer field.
+// Returns errNotMyType if no codec applies.
+func decodeWithRegisteredCodec(fieldValuePtr reflect.Value, value any, dbCodecs *codecRegistry) error {
+	return decodeWithCodec(fieldValuePtr, value, func(t reflect.Type) (codec, bool) {
+		return lookupCodec(dbCodecs, t)
+	})
+}
+
+// decodeWithBuiltinCodec deserializes value into the field using a built-in codec.
+// Returns errNotMyType if no codec applies.
+func decodeWithBuiltinCodec(fieldValuePtr reflect.Value, value any) error {
+	return decodeWithCodec(fieldValuePtr, value, builtinCodec)
+}
+
+func decodeWithCodec(fieldValuePtr reflect.Value, value any, lookup func(reflect.Type) (codec, bool)) error {
+	fieldElem := fieldValuePtr.Elem()
+	fieldType := fieldElem.Type()
+
+	if c, ok := lookup(fieldType); ok {
+		if value == nil {
+			fieldElem.Set(reflect.Zero(fieldType))
+			return nil
+		}
+		decoded, err := c.decode(value)
+		if err != nil {
+			return err
+		}
+		fieldElem.Set(decoded)
+		return nil
+	}
+
+	if fieldType.Kind() != reflect.Ptr {
+		return errNotMyType
+	}
+	c, ok := lookup(fieldType.Elem())
+	if !ok {
+		return errNotMyType
+	}
+	if value == nil {
+		fieldElem.Set(reflect.Zero(fieldType))
+		return nil
+	}
+	decoded, err := c.decode(value)
+	if err != nil {
+		return err
+	}
+	ptr := reflect.New(fieldType.Elem())
+	ptr.Elem().Set(decoded)
+	fieldElem.Set(ptr)
+	return nil
+}
+
+// encodeWithCodec serializes a non-nil field value using the codec found by lookup for its
+// type or, for pointers, its element type. ok is false if no codec applies.
+func encodeWithCodec(v reflect.Value, lookup func(reflect.Type) (codec, bool)) (value any, ok bool, err error) {
+	if c, found := lookup(v.Type()); found {
+		value, err = c.encode(v)
+		return value, true, err
+	}
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		if c, found := lookup(v.Type().Elem()); found {
+			value, err = c.encode(v.Elem())
+			return value, true, err
+		}
+	}
+	return nil, false, nil
+}
+
+var (
+	netIPType  = reflect.TypeOf(net.IP{})
+	urlURLType = reflect.TypeOf(url.URL{})
+)
+
+// builtinCodec returns the built-in codec for t:
+//   - Named 16-byte array types whose name ends in UUID (e.g., uuid.UUID), decoded from canonical,
+//     braced or unhyphenated hex strings or 16 raw bytes, and encoded as canonical strings.
+//     Other 16-byte arrays (e.g., MD5 digests) are binary values like any byte array.
+//   - net.IP, decoded from its text form or 4/16 raw bytes and encoded as text
+//   - url.URL, decoded with url.Parse and encoded with URL.String
+func builtinCodec(t reflect.Type) (codec, bool) {
+	switch {
+	case t == netIPType:
+		return netIPCodec, true
+	case t == urlURLType:
+		return urlCodec, true
+	case isUUIDType(t):
+		return uuidCodec(t), true
+	default:
+		return codec{}, false
+	}
+}
+
+var netIPCodec = codec{
+	decode: func(value any) (reflect.Value, error) {
+		var raw []byte
+		switch v := value.(type) {
+		case string:
+			if ip := net.ParseIP(v); ip != nil {
+				return reflect.ValueOf(ip), nil
+			}
+			raw = []byte(v)
+		case []byte:
+			if ip := net.ParseIP(string(v)); ip != nil {
+				return reflect.ValueOf(ip), nil
+			}
+			raw = v
+		case net.IP:
+			raw = v
+		default:
+			return reflect.Value{}, fmt.Errorf("typedb: cannot deserialize %T to net.IP", value)
+		}
+		if len(raw) != net.IPv4len && len(raw) != net.IPv6len {
+			return reflect.Value{}, fmt.Errorf("typedb: invalid IP address %q", value)
+		}
+		ip := make(net.IP, len(raw))
+		copy(ip, raw)
+		return reflect.ValueOf(ip), nil
+	},
+	encode: func(value reflect.Value) (any, error) {
+		ip, _ := value.Interface().(net.IP)
+		if len(ip) == 0 {
+			return nil, nil
+		}
+		return ip.String(), nil
+	},
+}
+
+var urlCodec = codec{
+	decode: func(value any) (reflect.Value, error) {
+		var s string
+		switch v := value.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return reflect.Value{}, fmt.Errorf("typedb: cannot deserialize %T to url.URL", value)
+		}
+		u, err := url.Parse(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(*u), nil
+	},
+	encode: func(value reflect.Value) (any, error) {
+		u, _ := value.Interface().(url.URL)
+		return u.String(), nil
+	},
+}
+
+// isUUIDType reports whether t is a named 16-byte array type whose name ends in UUID, case-insensitively.
+func isUUIDType(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8 &&
+		strings.HasSuffix(strings.ToLower(t.Name()), "uuid")
+}
+
+// uuidCodec returns a codec for a 16-byte array type t.
+func uuidCodec(t reflect.Type) codec {
+	return codec{
+		decode: func(value any) (reflect.Value, error) {
+			var raw []byte
+			switch v := value.(type) {
+			case string:
+				raw = []byte(v)
+			case []byte:
+				raw = v
+			default:
+				// Driver-native 16-byte arrays
+				if rv := reflect.ValueOf(value); rv.Kind() == reflect.Array && rv.Type().ConvertibleTo(t) {
+					return rv.Convert(t), nil
+				}
+				return reflect.Value{}, fmt.Errorf("typedb: cannot deserialize %T to %v", value, t)
+			}
+			b, err := parseUUID(raw)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result := reflect.New(t).Elem()
+			reflect.Copy(result, reflect.ValueOf(b[:]))
+			return result, nil
+		},
+		encode: func(value reflect.Value) (any, error) {
+			var b [16]byte
+			reflect.Copy(reflect.ValueOf(&b).Elem(), value)
+			return formatUUID(b), nil
+		},
+	}
+}
+
+// parseUUID parses 16 raw bytes or a hex UUID with optional hyphens, braces or urn:uuid: prefix.
+func parseUUID(raw []byte) ([16]byte, error) {
+	var b [16]byte
+	if len(raw) == 16 {
+		copy(b[:], raw)
+		return b, nil
+	}
+
+	s := strings.TrimPrefix(strings.ToLower(string(raw)), "urn:uuid:")
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+	if len(s) == 36 {
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return b, fmt.Errorf("typedb: invalid UUID %q", raw)
+		}
+		s = strings.ReplaceAll(s, "-", "")
+	}
+	if len(s) != 32 {
+		return b, fmt.Errorf("typedb: invalid UUID %q", raw)
+	}
+	if _, err := hex.Decode(b[:], []byte(s)); err != nil {
+		return b, fmt.Errorf("typedb: invalid UUID %q", raw)
+	}
+	return b, nil
+}
+
+// formatUUID formats b in canonical 8-4-4-4-12 form.
+func formatUUID(b [16]byte) string {
+	s := hex.EncodeToString(b[:])
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32]
+}
@@ -0,0 +1,284 @@
+package typedb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// CodecTestStatus is an enum stored as text
+type CodecTestStatus int
+
+const (
+	CodecTestStatusActive CodecTestStatus = iota + 1
+	CodecTestStatusBanned
+)
+
+func decodeCodecTestStatus(v any) (CodecTestStatus, error) {
+	switch fmt.Sprint(v) {
+	case "active":
+		return CodecTestStatusActive, nil
+	case "banned":
+		return CodecTestStatusBanned, nil
+	default:
+		return 0, fmt.Errorf("unknown status %v", v)
+	}
+}
+
+func encodeCodecTestStatus(s CodecTestStatus) (any, error) {
+	switch s {
+	case CodecTestStatusActive:
+		return "active", nil
+	case CodecTestStatusBanned:
+		return "banned", nil
+	default:
+		return nil, fmt.Errorf("invalid status %d", s)
+	}
+}
+
+// CodecTestUUID is a UUID-shaped type without Scanner/Valuer methods
+type CodecTestUUID [16]byte
+
+// CodecTestUser is a test model for codec tests
+type CodecTestUser struct {
+	Model
+	Homepage  *url.URL         `db:"homepage"`
+	IP        net.IP           `db:"ip"`
+	Previous  *CodecTestStatus `db:"previous"`
+	Status    CodecTestStatus  `db:"status"`
+	ID        int64            `db:"id" load:"primary"`
+	AccountID CodecTestUUID    `db:"account_id"`
+}
+
+func (u *CodecTestUser) TableName() string {
+	return "users"
+}
+
+func (u *CodecTestUser) QueryByID() string {
+	return "SELECT id, status, previous, account_id, ip, homepage FROM users WHERE id = ?"
+}
+
+// withGlobalCodecs isolates codecs registered with RegisterCodec to a single test.
+func withGlobalCodecs(t *testing.T) {
+	t.Helper()
+	previous := globalCodecs
+	globalCodecs = newCodecRegistry()
+	t.Cleanup(func() {
+		globalCodecs = previous
+	})
+}
+
+func TestRegisterCodec_Deserialize(t *testing.T) {
+	withGlobalCodecs(t)
+	RegisterCodec(decodeCodecTestStatus, encodeCodecTestStatus)
+
+	row := map[string]any{"id": int64(1), "status": "banned", "previous": "active"}
+	user, err := deserializeForType[*CodecTestUser](row)
+	if err != nil {
+		t.Fatalf("deserializeForType failed: %v", err)
+	}
+	if user.Status != CodecTestStatusBanned {
+		t.Errorf("Expected Status = banned, got %d", user.Status)
+	}
+	if user.Previous == nil || *user.Previous != CodecTestStatusActive {
+		t.Errorf("Expected Previous = active, got %v", user.Previous)
+	}
+
+	_, err = deserializeForType[*CodecTestUser](map[string]any{"status": "unknown"})
+	if err == nil || !strings.Contains(err.Error(), "field status") {
+		t.Errorf("Expected decode error naming the field, got %v", err)
+	}
+
+	user, err = deserializeForType[*CodecTestUser](map[string]any{"previous": nil})
+	if err != nil || user.Previous != nil {
+		t.Errorf("Expected NULL to leave pointer nil, got %v, %v", user.Previous, err)
+	}
+}
+
+func TestRegisterCodec_Serialize(t *testing.T) {
+	withGlobalCodecs(t)
+	RegisterCodec(decodeCodecTestStatus, encodeCodecTestStatus)
+
+	previous := CodecTestStatusActive
+	user := &CodecTestUser{Status: CodecTestStatusBanned, Previous: &previous}
+	columns, values, _, err := serializeModelFields(user, "ID")
+	if err != nil {
+		t.Fatalf("serializeModelFields failed: %v", err)
+	}
+
+	got := make(map[string]any)
+	for i, column := range columns {
+		got[column] = values[i]
+	}
+	if got["status"] != "banned" || got["previous"] != "active" {
+		t.Errorf("Expected encoded statuses, got %v", got)
+	}
+
+	_, _, _, _, err = serializeModelFieldsForUpdate(&CodecTestUser{Status: 99}, "ID", "postgres", nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid status 99") {
+		t.Errorf("Expected encode error, got %v", err)
+	}
+}
+
+func TestRegisterDBCodec_TakesPrecedence(t *testing.T) {
+	withGlobalCodecs(t)
+	RegisterCodec(decodeCodecTestStatus, encodeCodecTestStatus)
+
+	db := NewDB(nil, "postgres", 0)
+	RegisterDBCodec(db,
+		func(v any) (CodecTestStatus, error) { return CodecTestStatusActive, nil },
+		func(s CodecTestStatus) (any, error) { return int(s), nil },
+	)
+
+	opts := deserializeOptions{codecs: getExecutorCodecs(db)}
+	user, err := deserializeForTypeWithOptions[*CodecTestUser](map[string]any{"status": "banned"}, opts)
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if user.Status != CodecTestStatusActive {
+		t.Errorf("Expected DB codec to win, got %d", user.Status)
+	}
+
+	value, err := serializeFieldValue(reflect.ValueOf(CodecTestStatusBanned), newSerializeOptions(db))
+	if err != nil || value != 2 {
+		t.Errorf("Expected DB codec encoding 2, got %v, %v", value, err)
+	}
+
+	// Other DBs still use the global codec
+	value, err = serializeFieldValue(reflect.ValueOf(CodecTestStatusBanned), newSerializeOptions(&DB{}))
+	if err != nil || value != "banned" {
+		t.Errorf("Expected global codec encoding, got %v, %v", value, err)
+	}
+}
+
+func TestRegisterDBCodec_SharedWithOpenTransactions(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	tx, err := db.Begin(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			t.Errorf("Rollback failed: %v", err)
+		}
+	}()
+
+	RegisterDBCodec(db, decodeCodecTestStatus, encodeCodecTestStatus)
+	if _, ok := lookupCodec(getExecutorCodecs(tx), reflect.TypeOf(CodecTestStatus(0))); !ok {
+		t.Error("Expected a transaction begun before RegisterDBCodec to see the codec")
+	}
+}
+
+func TestBuiltinCodecs(t *testing.T) {
+	const canonical = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	want := CodecTestUUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	for _, input := range []any{canonical, strings.ToUpper(canonical), "{" + canonical + "}", "6ba7b8109dad11d180b400c04fd430c8", string(want[:]), want[:], [16]byte(want)} {
+		user, err := deserializeForType[*CodecTestUser](map[string]any{"account_id": input})
+		if err != nil {
+			t.Errorf("UUID from %q: %v", input, err)
+			continue
+		}
+		if user.AccountID != want {
+			t.Errorf("UUID from %q = %x, want %x", input, user.AccountID, want)
+		}
+	}
+	if _, err := deserializeForType[*CodecTestUser](map[string]any{"account_id": "not-a-uuid"}); err == nil {
+		t.Error("Expected invalid UUID error")
+	}
+
+	user, err := deserializeForType[*CodecTestUser](map[string]any{"ip": "192.168.1.10", "homepage": "https://example.com/a?b=c"})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if !user.IP.Equal(net.ParseIP("192.168.1.10")) {
+		t.Errorf("Expected IP 192.168.1.10, got %v", user.IP)
+	}
+	if user.Homepage == nil || user.Homepage.Host != "example.com" || user.Homepage.RawQuery != "b=c" {
+		t.Errorf("Unexpected homepage %v", user.Homepage)
+	}
+
+	user, err = deserializeForType[*CodecTestUser](map[string]any{"ip": string([]byte{10, 0, 0, 1})})
+	if err != nil || !user.IP.Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Errorf("Expected IP from raw bytes, got %v, %v", user.IP, err)
+	}
+
+	user.AccountID = want
+	user.Homepage, _ = url.Parse("https://example.com/a?b=c")
+	columns, values, _, err := serializeModelFields(user, "ID")
+	if err != nil {
+		t.Fatalf("serializeModelFields failed: %v", err)
+	}
+	got := make(map[string]any)
+	for i, column := range columns {
+		got[column] = values[i]
+	}
+	if got["account_id"] != canonical {
+		t.Errorf("Expected canonical UUID, got %v", got["account_id"])
+	}
+	if got["ip"] != "10.0.0.1" {
+		t.Errorf("Expected IP text, got %v", got["ip"])
+	}
+	if got["homepage"] != "https://example.com/a?b=c" {
+		t.Errorf("Expected URL text, got %v", got["homepage"])
+	}
+}
+
+func TestCodec_SQLiteRoundTrip(t *testing.T) {
+	withGlobalCodecs(t)
+
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1),
+		WithCodec(decodeCodecTestStatus, encodeCodecTestStatus))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, status TEXT, previous TEXT, account_id TEXT, ip TEXT, homepage TEXT)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	homepage, _ := url.Parse("https://example.com")
+	user := &CodecTestUser{
+		Status:    CodecTestStatusActive,
+		AccountID: CodecTestUUID{1, 2, 3},
+		IP:        net.ParseIP("::1"),
+		Homepage:  homepage,
+	}
+	if err := Insert(ctx, db, user); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	var status string
+	if err := db.GetInto(ctx, "SELECT status FROM users WHERE id = ?", []any{user.ID}, &status); err != nil {
+		t.Fatalf("GetInto failed: %v", err)
+	}
+	if status != "active" {
+		t.Errorf("Expected stored status 'active', got %q", status)
+	}
+
+	tx, err := db.Begin(ctx, nil)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	loaded := &CodecTestUser{ID: user.ID}
+	if err := Load(ctx, tx, loaded); err != nil {
+		t.Fatalf("Load in transaction failed: %v", err)
+	}
+	if loaded.Status != CodecTestStatusActive || loaded.AccountID != user.AccountID || !loaded.IP.Equal(user.IP) || loaded.Homepage.String() != "https://example.com" {
+		t.Errorf("Round trip mismatch: %+v", loaded)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package typedb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// duplicateColumnSeparator separates a repeated column name from its occurrence number in row maps.
+// The first occurrence of a column keeps its name; later ones are keyed "name#2", "name#3", ...
+const duplicateColumnSeparator = "#"
+
+// duplicateColumnKeysKey marks a context whose row maps are deserialized into structs.
+type duplicateColumnKeysKey struct{}
+
+// withDuplicateColumnKeys marks ctx so the executor keys repeated result columns by occurrence.
+// Only struct deserialization sets it; raw row maps from Executor.QueryAll and QueryRowMap keep
+// the last value of a repeated column under its name.
+func withDuplicateColumnKeys(ctx context.Context) context.Context {
+	return context.WithValue(ctx, duplicateColumnKeysKey{}, true)
+}
+
+// hasDuplicateColumnKeys reports whether ctx was marked by withDuplicateColumnKeys.
+func hasDuplicateColumnKeys(ctx context.Context) bool {
+	keep, _ := ctx.Value(duplicateColumnKeysKey{}).(bool)
+	return keep
+}
+
+// columnKeys returns the row map key for each result column.
+// Names are lowercased unless preserveCase is set. When keepDuplicates is set, names that repeat
+// after case folding (e.g., "id" in SELECT u.id, p.id) get an occurrence suffix so no value is lost;
+// otherwise they share a key and the last value wins.
+func columnKeys(cols []string, preserveCase, keepDuplicates bool) []string {
+	keys := make([]string, len(cols))
+	seen := make(map[string]int, len(cols))
+	for i, col := range cols {
+		key := col
+		if !preserveCase {
+			key = strings.ToLower(col)
+		}
+		seen[key]++
+		if n := seen[key]; n > 1 && keepDuplicates {
+			key += duplicateColumnSeparator + strconv.Itoa(n)
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+// splitDuplicateColumn splits a row map key produced by columnKeys into the column name and
+// its occurrence number. ok is false for keys without an occurrence suffix.
+func splitDuplicateColumn(key string) (name string, occurrence int, ok bool) {
+	i := strings.LastIndex(key, duplicateColumnSeparator)
+	if i <= 0 {
+		return key, 1, false
+	}
+	n, err := strconv.Atoi(key[i+1:])
+	if err != nil || n < 2 {
+		return key, 1, false
+	}
+	return key[:i], n, true
+}
+
+// dbTagsCache maps a struct type to its db tags in field declaration order.
+var dbTagsCache sync.Map // map[reflect.Type][]string
+
+// orderedDBTags returns the db tags of a struct type in field declaration order, including embedded structs.
+func orderedDBTags(t reflect.Type) []string {
+	if cached, ok := dbTagsCache.Load(t); ok {
+		return cached.([]string)
+	}
+
+	var tags []string
+	var collect func(reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					collect(embeddedType)
+					continue
+				}
+			}
+			dbTag := field.Tag.Get("db")
+			if dbTag == "" || dbTag == "-" || dbTag == collectColumnsTag {
+				continue
+			}
+			tags = append(tags, dbTag)
+		}
+	}
+	collect(t)
+
+	dbTagsCache.Store(t, tags)
+	return tags
+}
+
+// resolveColumnNames maps the keys of row onto the db tags of structType.
+//
+// Repeated columns are assigned positionally: the occurrences of "id" fill, in field order,
+// the fields tagged "id" or "<table>.id" (e.g., db:"users.id", db:"posts.id") whose tag is not
+// itself a column of the row. Occurrences left without a field are returned as unresolved.
+//
+// When preserveCase is set, a column that matches no tag exactly is mapped to the single tag
+// that matches it case-insensitively, if that tag is not itself a column of the row.
+//
+// row is returned unchanged when there is nothing to resolve.
+func resolveColumnNames(row map[string]any, structType reflect.Type, preserveCase bool) (resolved map[string]any, unresolved []string) {
+	occurrences := make(map[string][]string)
+	for key := range row {
+		if name, _, ok := splitDuplicateColumn(key); ok {
+			occurrences[name] = append(occurrences[name], key)
+		}
+	}
+	if len(occurrences) == 0 && !preserveCase {
+		return row, nil
+	}
+
+	tags := orderedDBTags(structType)
+	resolved = make(map[string]any, len(row))
+	for key, value := range row {
+		resolved[key] = value
+	}
+
+	for name, keys := range occurrences {
+		if _, ok := row[name]; !ok {
+			// Without a first occurrence the keys are not ours (e.g., a column named "x#2")
+			continue
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			_, a, _ := splitDuplicateColumn(keys[i])
+			_, b, _ := splitDuplicateColumn(keys[j])
+			return a < b
+		})
+		keys = append([]string{name}, keys...)
+
+		var targets []string
+		for _, tag := range tags {
+			if tag != name && !strings.HasSuffix(tag, "."+name) {
+				continue
+			}
+			if _, taken := row[tag]; taken && tag != name {
+				continue
+			}
+			targets = append(targets, tag)
+		}
+
+		for i, key := range keys {
+			if i >= len(targets) {
+				unresolved = append(unresolved, key)
+				continue
+			}
+			value := row[key]
+			delete(resolved, key)
+			resolved[targets[i]] = value
+		}
+	}
+
+	if preserveCase {
+		foldCase(resolved, tags)
+	}
+
+	sort.Strings(unresolved)
+	return resolved, unresolved
+}
+
+// foldCase renames keys of row that match no tag exactly to the unique tag that matches them
+// case-insensitively, unless that tag is already a key of row.
+func foldCase(row map[string]any, tags []string) {
+	byLower := make(map[string][]string, len(tags))
+	exact := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		exact[tag] = true
+		lower := strings.ToLower(tag)
+		byLower[lower] = append(byLower[lower], tag)
+	}
+
+	for key, value := range row {
+		if exact[key] || key == collectColumnsTag {
+			continue
+		}
+		candidates := byLower[strings.ToLower(key)]
+		if len(candidates) != 1 {
+			continue
+		}
+		if _, taken := row[candidates[0]]; taken {
+			continue
+		}
+		delete(row, key)
+		row[candidates[0]] = value
+	}
+}
+
+// checkDuplicateColumns enforces the duplicate column flags of policy for repeated columns
+// that could not be mapped to a field.
+func checkDuplicateColumns(policy ColumnPolicy, unresolved []string, logger Logger) error {
+	if len(unresolved) == 0 {
+		return nil
+	}
+	if policy.has(ColumnPolicyErrorOnDuplicate) {
+		return fmt.Errorf("%w: %s", ErrDuplicateColumn, strings.Join(unresolved, ", "))
+	}
+	if policy.has(ColumnPolicyWarnOnDuplicate) {
+		getLoggerHelper(logger).Warn("Duplicate result columns have no matching field", "columns", unresolved)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// ColumnNamesTestPost is a joined test model whose users.id and posts.id columns share the name "id"
+type ColumnNamesTestPost struct {
+	Model
+	Title  string `db:"title"`
+	UserID int64  `db:"users.id"`
+	PostID int64  `db:"posts.id"`
+}
+
+// ColumnNamesTestUser is a test model with a mixed-case db tag
+type ColumnNamesTestUser struct {
+	Model
+	DisplayName string `db:"displayName"`
+	Name        string `db:"name"`
+	ID          int64  `db:"id" load:"primary"`
+}
+
+func TestColumnKeys(t *testing.T) {
+	got := columnKeys([]string{"id", "Name", "ID", "name", "id"}, false, true)
+	want := []string{"id", "name", "id#2", "name#2", "id#3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("columnKeys() = %v, want %v", got, want)
+	}
+
+	got = columnKeys([]string{"id", "Name", "ID"}, false, false)
+	want = []string{"id", "name", "id"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("columnKeys(without duplicates) = %v, want %v", got, want)
+	}
+
+	got = columnKeys([]string{"id", "ID", "userId", "userid"}, true, true)
+	want = []string{"id", "ID", "userId", "userid"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("columnKeys(preserveCase) = %v, want %v", got, want)
+	}
+}
+
+func TestResolveColumnNames_Positional(t *testing.T) {
+	post, err := deserializeForType[*ColumnNamesTestPost](map[string]any{"id": int64(1), "id#2": int64(7), "title": "Hello"})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if post.UserID != 1 || post.PostID != 7 || post.Title != "Hello" {
+		t.Errorf("Expected positional mapping of id columns, got %+v", post)
+	}
+
+	// An explicitly aliased column is not reassigned
+	post, err = deserializeForType[*ColumnNamesTestPost](map[string]any{"posts.id": int64(7), "id": int64(1), "id#2": int64(2)})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if post.UserID != 1 || post.PostID != 7 {
+		t.Errorf("Expected aliased posts.id to win, got %+v", post)
+	}
+}
+
+func TestResolveColumnNames_DuplicatePolicy(t *testing.T) {
+	row := map[string]any{"id": int64(1), "id#2": int64(7), "name": "Alice"}
+
+	user, err := deserializeForType[*ColumnNamesTestUser](row)
+	if err != nil || user.ID != 1 {
+		t.Errorf("Expected first id to be used by default, got %+v, %v", user, err)
+	}
+
+	_, err = deserializeForTypeWithOptions[*ColumnNamesTestUser](row, deserializeOptions{dbColumnPolicy: ColumnPolicyErrorOnDuplicate})
+	if !errors.Is(err, ErrDuplicateColumn) {
+		t.Errorf("Expected ErrDuplicateColumn, got %v", err)
+	}
+
+	logger := &testLogger{}
+	_, err = deserializeForTypeWithOptions[*ColumnNamesTestUser](row, deserializeOptions{dbColumnPolicy: ColumnPolicyWarnOnDuplicate, logger: logger})
+	if err != nil {
+		t.Fatalf("Expected warning only, got %v", err)
+	}
+	if len(logger.warns) != 1 || !reflect.DeepEqual(logger.warns[0].keyvals, []any{"columns", []string{"id#2"}}) {
+		t.Errorf("Expected one duplicate column warning, got %+v", logger.warns)
+	}
+}
+
+func TestColumnNames_SQLiteJoin(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1), WithColumnPolicy(ColumnPolicyErrorOnDuplicate))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, displayName TEXT)",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER, title TEXT)",
+		"INSERT INTO users (id, name, displayName) VALUES (3, 'alice', 'Alice')",
+		"INSERT INTO posts (id, user_id, title) VALUES (9, 3, 'Hello')",
+	} {
+		if _, err := db.Exec(ctx, stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+
+	query := "SELECT u.id, p.id, p.title FROM users u JOIN posts p ON p.user_id = u.id"
+	row, err := db.QueryRowMap(ctx, query)
+	if err != nil {
+		t.Fatalf("QueryRowMap failed: %v", err)
+	}
+	if !reflect.DeepEqual(row, map[string]any{"id": int64(9), "title": "Hello"}) {
+		t.Errorf("Expected raw row maps to keep the last id column, got %v", row)
+	}
+	rows, err := db.QueryAll(ctx, query)
+	if err != nil {
+		t.Fatalf("QueryAll failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["id"] != int64(9) || rows[0]["id#2"] != nil {
+		t.Errorf("Expected raw row maps to keep the last id column, got %v", rows)
+	}
+
+	posts, err := QueryAll[*ColumnNamesTestPost](ctx, db, query)
+	if err != nil {
+		t.Fatalf("QueryAll failed: %v", err)
+	}
+	if len(posts) != 1 || posts[0].UserID != 3 || posts[0].PostID != 9 {
+		t.Errorf("Unexpected posts %+v", posts)
+	}
+
+	_, err = QueryAll[*ColumnNamesTestUser](ctx, db, "SELECT u.id, p.id, u.name FROM users u JOIN posts p ON p.user_id = u.id")
+	if !errors.Is(err, ErrDuplicateColumn) {
+		t.Errorf("Expected ErrDuplicateColumn, got %v", err)
+	}
+}
+
+func TestColumnNames_SQLitePreserveCase(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1), WithPreserveColumnCase(true))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, displayName TEXT)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	if _, err := db.Exec(ctx, "INSERT INTO users (id, name, displayName) VALUES (1, 'alice', 'Alice')"); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	row, err := db.QueryRowMap(ctx, "SELECT id AS ID, displayName, name AS Name FROM users")
+	if err != nil {
+		t.Fatalf("QueryRowMap failed: %v", err)
+	}
+	if !reflect.DeepEqual(row, map[string]any{"ID": int64(1), "displayName": "Alice", "Name": "alice"}) {
+		t.Errorf("Expected column case to be preserved, got %v", row)
+	}
+
+	user, err := QueryFirst[*ColumnNamesTestUser](ctx, db, "SELECT id AS ID, displayName, name AS Name FROM users")
+	if err != nil {
+		t.Fatalf("QueryFirst failed: %v", err)
+	}
+	if user.ID != 1 || user.Name != "alice" || user.DisplayName != "Alice" {
+		t.Errorf("Expected case-insensitive fallback to db tags, got %+v", user)
+	}
+}
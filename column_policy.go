@@ -0,0 +1,238 @@
+package typedb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ColumnPolicy controls how deserialization treats result columns that have no matching
+// db tag, and db-tagged fields that are absent from the result.
+// Policies are flags and may be combined (e.g., ColumnPolicyCollect | ColumnPolicyErrorOnMissing).
+//
+// A policy can be set per DB (WithColumnPolicy), per model (ModelOptions.ColumnPolicy) and
+// per call (WithColumnPolicyOverride). The most specific non-zero policy wins:
+// context override, then model, then DB. If none is set, ColumnPolicyIgnore applies.
+type ColumnPolicy uint8
+
+const (
+	// ColumnPolicyIgnore silently ignores unmapped columns and missing fields.
+	ColumnPolicyIgnore ColumnPolicy = 1 << iota
+
+	// ColumnPolicyErrorOnUnknown returns an error wrapping ErrUnknownColumn when the result
+	// contains a column that does not map to any db tag.
+	ColumnPolicyErrorOnUnknown
+
+	// ColumnPolicyErrorOnMissing returns an error wrapping ErrMissingColumn when a db-tagged
+	// field has no matching column in the result.
+	ColumnPolicyErrorOnMissing
+
+	// ColumnPolicyCollect stores unmapped columns in the model's db:"*" field, which must be
+	// of type map[string]any. Takes precedence over ColumnPolicyErrorOnUnknown.
+	ColumnPolicyCollect
+
+	// ColumnPolicyErrorOnDuplicate returns an error wrapping ErrDuplicateColumn when the result
+	// repeats a column name (e.g., SELECT u.id, p.id) and a repeat cannot be mapped positionally
+	// to a dot-notation db tag.
+	ColumnPolicyErrorOnDuplicate
+
+	// ColumnPolicyWarnOnDuplicate logs a warning instead of returning an error for such repeats.
+	ColumnPolicyWarnOnDuplicate
+)
+
+// collectColumnsTag is the db tag of the field that receives unmapped columns under ColumnPolicyCollect.
+const collectColumnsTag = "*"
+
+// has reports whether all flags in flag are set.
+func (p ColumnPolicy) has(flag ColumnPolicy) bool {
+	return p&flag == flag
+}
+
+// String implements fmt.Stringer.
+func (p ColumnPolicy) String() string {
+	if p == 0 {
+		return "inherit"
+	}
+	var names []string
+	for _, f := range []struct {
+		flag ColumnPolicy
+		name string
+	}{
+		{ColumnPolicyIgnore, "ignore"},
+		{ColumnPolicyErrorOnUnknown, "error-on-unknown"},
+		{ColumnPolicyErrorOnMissing, "error-on-missing"},
+		{ColumnPolicyCollect, "collect"},
+		{ColumnPolicyErrorOnDuplicate, "error-on-duplicate"},
+		{ColumnPolicyWarnOnDuplicate, "warn-on-duplicate"},
+	} {
+		if p.has(f.flag) {
+			names = append(names, f.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+type columnPolicyKey struct{}
+
+// WithColumnPolicyOverride sets the column policy for the specific operation.
+// This overrides the model's ModelOptions.ColumnPolicy and the DB's WithColumnPolicy setting.
+//
+// Example:
+//
+//	ctx = typedb.WithColumnPolicyOverride(ctx, typedb.ColumnPolicyErrorOnUnknown)
+//	users, err := typedb.QueryAll[*User](ctx, db, "SELECT * FROM users")
+func WithColumnPolicyOverride(ctx context.Context, policy ColumnPolicy) context.Context {
+	return context.WithValue(ctx, columnPolicyKey{}, policy)
+}
+
+// getColumnPolicyOverride extracts the column policy override from context.
+func getColumnPolicyOverride(ctx context.Context) ColumnPolicy {
+	policy, _ := ctx.Value(columnPolicyKey{}).(ColumnPolicy)
+	return policy
+}
+
+// getExecutorColumnPolicy extracts the DB-level column policy from an Executor.
+func getExecutorColumnPolicy(exec Executor) ColumnPolicy {
+	switch e := exec.(type) {
+	case *DB:
+		return e.columnPolicy
+	case *Tx:
+		return e.columnPolicy
+	default:
+		return 0
+	}
+}
+
+// getExecutorLogger extracts the logger from an Executor, or nil for other implementations.
+func getExecutorLogger(exec Executor) Logger {
+	switch e := exec.(type) {
+	case *DB:
+		return e.logger
+	case *Tx:
+		return e.logger
+	default:
+		return nil
+	}
+}
+
+// getExecutorPreserveColumnCase reports whether an Executor keeps the case of result column names.
+func getExecutorPreserveColumnCase(exec Executor) bool {
+	switch e := exec.(type) {
+	case *DB:
+		return e.preserveColumnCase
+	case *Tx:
+		return e.preserveColumnCase
+	default:
+		return false
+	}
+}
+
+// resolveColumnPolicy returns the first non-zero policy, defaulting to ColumnPolicyIgnore.
+func resolveColumnPolicy(policies ...ColumnPolicy) ColumnPolicy {
+	for _, p := range policies {
+		if p != 0 {
+			return p
+		}
+	}
+	return ColumnPolicyIgnore
+}
+
+// applyColumnPolicy enforces policy after the mapped columns of row have been deserialized.
+// fieldMap maps db tags to field pointers as built by buildFieldMapFromPtr.
+func applyColumnPolicy(policy ColumnPolicy, row map[string]any, fieldMap map[string]reflect.Value) error {
+	collect := policy.has(ColumnPolicyCollect)
+	if !collect && !policy.has(ColumnPolicyErrorOnUnknown) && !policy.has(ColumnPolicyErrorOnMissing) {
+		return nil
+	}
+
+	var unknown []string
+	for column := range row {
+		if column == collectColumnsTag {
+			unknown = append(unknown, column)
+			continue
+		}
+		if _, ok := fieldMap[column]; !ok {
+			unknown = append(unknown, column)
+		}
+	}
+	sort.Strings(unknown)
+
+	if collect {
+		target, ok := fieldMap[collectColumnsTag]
+		if !ok {
+			return fmt.Errorf("typedb: column policy %q requires a map[string]any field tagged db:\"*\"", policy)
+		}
+		extra := make(map[string]any, len(unknown))
+		for _, column := range unknown {
+			extra[column] = row[column]
+		}
+		target.Elem().Set(reflect.ValueOf(extra))
+	} else if policy.has(ColumnPolicyErrorOnUnknown) && len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownColumn, strings.Join(unknown, ", "))
+	}
+
+	if policy.has(ColumnPolicyErrorOnMissing) {
+		var missing []string
+		for tag := range fieldMap {
+			if tag == collectColumnsTag {
+				continue
+			}
+			if _, ok := row[tag]; !ok {
+				missing = append(missing, tag)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return fmt.Errorf("%w: %s", ErrMissingColumn, strings.Join(missing, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateCollectField checks db:"*" fields: at most one, of type map[string]any.
+func validateCollectField(t reflect.Type) []string {
+	var errors []string
+	count := 0
+
+	var check func(reflect.Type)
+	check = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					check(embeddedType)
+					continue
+				}
+			}
+			if field.Tag.Get("db") != collectColumnsTag {
+				continue
+			}
+			count++
+			if field.Type != reflect.TypeOf(map[string]any{}) {
+				errors = append(errors, fmt.Sprintf("field %s tagged db:\"*\" must be of type map[string]any (is %v)", field.Name, field.Type))
+			}
+		}
+	}
+
+	check(t)
+	if count > 1 {
+		errors = append(errors, "multiple fields tagged db:\"*\" found (only one allowed)")
+	}
+	return errors
+}
+
+// hasCollectField reports whether a struct type has a field tagged db:"*".
+func hasCollectField(t reflect.Type) bool {
+	_, found := findFieldByTagRecursive(t, "db", collectColumnsTag)
+	return found
+}
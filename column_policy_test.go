@@ -0,0 +1,219 @@
+package typedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// ColumnPolicyUser is a test model for column policy tests
+type ColumnPolicyUser struct {
+	Model
+	Extra map[string]any `db:"*"`
+	Name  string         `db:"name"`
+	ID    int64          `db:"id" load:"primary"`
+}
+
+func (u *ColumnPolicyUser) QueryByID() string {
+	return "SELECT id, name FROM users WHERE id = $1"
+}
+
+// ColumnPolicyBadCollect has a db:"*" field of the wrong type
+type ColumnPolicyBadCollect struct {
+	Model
+	Extra map[string]string `db:"*"`
+	ID    int64             `db:"id"`
+}
+
+// withModelOptions sets options for a model type for the duration of a test.
+func withModelOptions(t *testing.T, modelType reflect.Type, opts ModelOptions) {
+	t.Helper()
+	registerMutex.Lock()
+	previous, existed := modelOptions[modelType]
+	modelOptions[modelType] = opts
+	registerMutex.Unlock()
+
+	t.Cleanup(func() {
+		registerMutex.Lock()
+		defer registerMutex.Unlock()
+		if existed {
+			modelOptions[modelType] = previous
+		} else {
+			delete(modelOptions, modelType)
+		}
+	})
+}
+
+func columnPolicyMock(row map[string]any) *MockExecutor {
+	return &MockExecutor{
+		QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+			return []map[string]any{row}, nil
+		},
+		QueryRowMapFunc: func(ctx context.Context, query string, args ...any) (map[string]any, error) {
+			return row, nil
+		},
+	}
+}
+
+func TestColumnPolicy_Ignore(t *testing.T) {
+	ctx := context.Background()
+	mock := columnPolicyMock(map[string]any{"id": int64(1), "nickname": "al"})
+
+	users, err := QueryAll[*ColumnPolicyUser](ctx, mock, "SELECT id, nickname FROM users")
+	if err != nil {
+		t.Fatalf("Expected default policy to ignore columns, got %v", err)
+	}
+	if users[0].ID != 1 || users[0].Extra != nil {
+		t.Errorf("Unexpected result: %+v", users[0])
+	}
+}
+
+func TestColumnPolicy_ErrorOnUnknown(t *testing.T) {
+	ctx := WithColumnPolicyOverride(context.Background(), ColumnPolicyErrorOnUnknown)
+	mock := columnPolicyMock(map[string]any{"id": int64(1), "name": "Alice", "zeta": 1, "alpha": 2})
+
+	_, err := QueryOne[*ColumnPolicyUser](ctx, mock, "SELECT * FROM users")
+	if !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("Expected ErrUnknownColumn, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "alpha, zeta") {
+		t.Errorf("Expected sorted column names in error, got %v", err)
+	}
+}
+
+func TestColumnPolicy_ErrorOnMissing(t *testing.T) {
+	ctx := WithColumnPolicyOverride(context.Background(), ColumnPolicyErrorOnMissing)
+
+	_, err := QueryFirst[*ColumnPolicyUser](ctx, columnPolicyMock(map[string]any{"id": int64(1)}), "SELECT id FROM users")
+	if !errors.Is(err, ErrMissingColumn) {
+		t.Fatalf("Expected ErrMissingColumn, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "name") {
+		t.Errorf("Expected missing column in error, got %v", err)
+	}
+
+	// NULL values still count as present
+	_, err = QueryFirst[*ColumnPolicyUser](ctx, columnPolicyMock(map[string]any{"id": int64(1), "name": nil}), "SELECT id, name FROM users")
+	if err != nil {
+		t.Errorf("Expected no error when all columns present, got %v", err)
+	}
+}
+
+func TestColumnPolicy_Collect(t *testing.T) {
+	ctx := WithColumnPolicyOverride(context.Background(), ColumnPolicyCollect|ColumnPolicyErrorOnMissing)
+	mock := columnPolicyMock(map[string]any{"id": int64(1), "name": "Alice", "score": int64(42)})
+
+	users, err := QueryAll[*ColumnPolicyUser](ctx, mock, "SELECT id, name, score FROM users")
+	if err != nil {
+		t.Fatalf("QueryAll failed: %v", err)
+	}
+	want := map[string]any{"score": int64(42)}
+	if !reflect.DeepEqual(users[0].Extra, want) {
+		t.Errorf("Expected Extra = %v, got %v", want, users[0].Extra)
+	}
+	if users[0].Name != "Alice" {
+		t.Errorf("Expected Name = Alice, got %q", users[0].Name)
+	}
+}
+
+func TestColumnPolicy_CollectRequiresField(t *testing.T) {
+	ctx := WithColumnPolicyOverride(context.Background(), ColumnPolicyCollect)
+	mock := columnPolicyMock(map[string]any{"id": int64(1), "name": "Alice"})
+
+	_, err := QueryOne[*QueryMapTestPost](ctx, mock, "SELECT id, name FROM posts")
+	if err == nil || !strings.Contains(err.Error(), `db:"*"`) {
+		t.Errorf("Expected error about missing db:\"*\" field, got %v", err)
+	}
+}
+
+func TestColumnPolicy_Precedence(t *testing.T) {
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite: %v", err)
+	}
+	defer sqlDB.Close()
+
+	db := NewDB(sqlDB, "sqlite3", 5*time.Second)
+	db.columnPolicy = ColumnPolicyErrorOnUnknown
+	query := "SELECT 1 AS id, 'Alice' AS name, 'x' AS nickname"
+	ctx := context.Background()
+
+	if _, err := QueryOne[*ColumnPolicyUser](ctx, db, query); !errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("Expected DB policy to apply, got %v", err)
+	}
+
+	tx, err := db.Begin(ctx, nil)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if _, err := QueryOne[*ColumnPolicyUser](ctx, tx, query); !errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("Expected transaction to inherit DB policy, got %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+
+	withModelOptions(t, reflect.TypeOf(ColumnPolicyUser{}), ModelOptions{ColumnPolicy: ColumnPolicyCollect})
+	user, err := QueryOne[*ColumnPolicyUser](ctx, db, query)
+	if err != nil {
+		t.Fatalf("Expected model policy to override DB policy, got %v", err)
+	}
+	if user.Extra["nickname"] != "x" {
+		t.Errorf("Expected collected nickname, got %v", user.Extra)
+	}
+
+	ctx = WithColumnPolicyOverride(ctx, ColumnPolicyIgnore)
+	user, err = QueryOne[*ColumnPolicyUser](ctx, db, query)
+	if err != nil {
+		t.Fatalf("Expected context override to win, got %v", err)
+	}
+	if user.Extra != nil {
+		t.Errorf("Expected Extra to stay nil under ColumnPolicyIgnore, got %v", user.Extra)
+	}
+}
+
+func TestColumnPolicy_CollectFieldExcludedFromWrites(t *testing.T) {
+	user := &ColumnPolicyUser{Name: "Alice", Extra: map[string]any{"score": 1}}
+
+	columns, _, _, err := serializeModelFields(user, "ID")
+	if err != nil {
+		t.Fatalf("serializeModelFields failed: %v", err)
+	}
+	if !reflect.DeepEqual(columns, []string{"name"}) {
+		t.Errorf("Expected only name column, got %v", columns)
+	}
+	if collectDBTags(reflect.TypeOf(ColumnPolicyUser{}))[collectColumnsTag] {
+		t.Error("Expected db:\"*\" to be excluded from query validation tags")
+	}
+}
+
+func TestValidateModel_CollectField(t *testing.T) {
+	if err := ValidateModel(&ColumnPolicyUser{}); err != nil {
+		t.Errorf("Expected valid model, got %v", err)
+	}
+
+	err := ValidateModel(&ColumnPolicyBadCollect{})
+	if err == nil || !strings.Contains(err.Error(), "must be of type map[string]any") {
+		t.Errorf("Expected db:\"*\" type error, got %v", err)
+	}
+}
+
+func TestColumnPolicy_String(t *testing.T) {
+	tests := map[ColumnPolicy]string{
+		0:                          "inherit",
+		ColumnPolicyIgnore:         "ignore",
+		ColumnPolicyErrorOnUnknown: "error-on-unknown",
+		ColumnPolicyCollect | ColumnPolicyErrorOnMissing: "error-on-missing|collect",
+	}
+	for policy, want := range tests {
+		if got := policy.String(); got != want {
+			t.Errorf("ColumnPolicy(%d).String() = %q, want %q", policy, got, want)
+		}
+	}
+}
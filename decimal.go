@@ -0,0 +1,300 @@
+package typedb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number for NUMERIC/DECIMAL columns, stored as an arbitrary-precision
+// unscaled integer and a scale (the number of digits after the decimal point).
+// The zero value is 0. Decimals are immutable; arithmetic methods return new values.
+//
+// Decimal implements sql.Scanner, driver.Valuer, json.Marshaler and json.Unmarshaler, so it can be
+// used directly as a model field. Use *Decimal for nullable columns.
+type Decimal struct {
+	unscaled *big.Int // nil means zero
+	scale    int32
+}
+
+var decimalType = reflect.TypeOf(Decimal{})
+
+// NewDecimal returns unscaled * 10^-scale, e.g. NewDecimal(12345, 2) is 123.45.
+// A negative scale multiplies by a power of ten.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return newDecimal(big.NewInt(unscaled), scale)
+}
+
+// NewDecimalFromInt returns the integer n as a Decimal with scale 0.
+func NewDecimalFromInt(n int64) Decimal {
+	return NewDecimal(n, 0)
+}
+
+// NewDecimalFromFloat returns the shortest decimal that round-trips to f, e.g. 0.1 becomes exactly 0.1.
+// Returns an error for NaN and infinities.
+func NewDecimalFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("typedb: cannot convert %v to Decimal", f)
+	}
+	return ParseDecimal(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// ParseDecimal parses a decimal string such as "123.45", "-0.001", "+7" or "1.5e-3".
+func ParseDecimal(s string) (Decimal, error) {
+	text := strings.TrimSpace(s)
+
+	mantissa, exponent := text, int64(0)
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		exp, err := strconv.ParseInt(text[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("typedb: invalid decimal %q", s)
+		}
+		mantissa, exponent = text[:i], exp
+	}
+
+	whole, frac, _ := strings.Cut(mantissa, ".")
+	digits := whole + frac
+	sign := ""
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+	if digits == "" || strings.ContainsAny(digits, "+-") {
+		return Decimal{}, fmt.Errorf("typedb: invalid decimal %q", s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("typedb: invalid decimal %q", s)
+	}
+
+	scale := int64(len(frac)) - exponent
+	if scale > math.MaxInt32 || scale < math.MinInt32 {
+		return Decimal{}, fmt.Errorf("typedb: decimal %q is out of range", s)
+	}
+	return newDecimal(unscaled, int32(scale)), nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s is not a valid decimal.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// newDecimal builds a Decimal, folding negative scales into the unscaled value.
+func newDecimal(unscaled *big.Int, scale int32) Decimal {
+	if scale < 0 {
+		unscaled = new(big.Int).Mul(unscaled, pow10(-int64(scale)))
+		scale = 0
+	}
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+// Unscaled returns a copy of the unscaled integer value.
+func (d Decimal) Unscaled() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(d.unscaled)
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1.
+func (d Decimal) Sign() int {
+	if d.unscaled == nil {
+		return 0
+	}
+	return d.unscaled.Sign()
+}
+
+// IsZero reports whether d is numerically zero.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Rat returns d as an exact rational number.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.Unscaled(), pow10(int64(d.scale)))
+}
+
+// Float64 returns the nearest float64 to d and whether the conversion was exact.
+func (d Decimal) Float64() (float64, bool) {
+	return d.Rat().Float64()
+}
+
+// Cmp compares d and other numerically, ignoring scale: -1 if d < other, 0 if equal, +1 if d > other.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := d.rescaled(other)
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and other are numerically equal (e.g., 1.5 equals 1.50).
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// Add returns d + other with the larger of the two scales.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b := d.rescaled(other)
+	return Decimal{unscaled: a.Add(a, b), scale: max(d.scale, other.scale)}
+}
+
+// Sub returns d - other with the larger of the two scales.
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b := d.rescaled(other)
+	return Decimal{unscaled: a.Sub(a, b), scale: max(d.scale, other.scale)}
+}
+
+// Mul returns d * other with the sum of the two scales.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.Unscaled(), other.Unscaled()), scale: d.scale + other.scale}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.Unscaled()), scale: d.scale}
+}
+
+// rescaled returns copies of the unscaled values of d and other at their common (larger) scale.
+func (d Decimal) rescaled(other Decimal) (*big.Int, *big.Int) {
+	a, b := d.Unscaled(), other.Unscaled()
+	switch {
+	case d.scale < other.scale:
+		a.Mul(a, pow10(int64(other.scale-d.scale)))
+	case d.scale > other.scale:
+		b.Mul(b, pow10(int64(d.scale-other.scale)))
+	}
+	return a, b
+}
+
+// normalized returns d with trailing fractional zeros removed, e.g. 1.500 becomes 1.5.
+func (d Decimal) normalized() Decimal {
+	unscaled, scale := d.Unscaled(), d.scale
+	ten := big.NewInt(10)
+	remainder := new(big.Int)
+	for scale > 0 {
+		quotient, rem := new(big.Int).QuoRem(unscaled, ten, remainder)
+		if rem.Sign() != 0 {
+			break
+		}
+		unscaled, scale = quotient, scale-1
+	}
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+// String returns d in plain notation with exactly Scale() fractional digits, e.g. "-0.050".
+func (d Decimal) String() string {
+	digits := d.Unscaled().String()
+	negative := strings.HasPrefix(digits, "-")
+	digits = strings.TrimPrefix(digits, "-")
+
+	if d.scale > 0 {
+		if pad := int(d.scale) - len(digits) + 1; pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		point := len(digits) - int(d.scale)
+		digits = digits[:point] + "." + digits[point:]
+	}
+	if negative {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Scan implements sql.Scanner. Accepts strings, []byte, integers and floats; NULL sets d to zero.
+// Use *Decimal fields to keep NULL distinct from zero.
+func (d *Decimal) Scan(src any) error {
+	var (
+		parsed Decimal
+		err    error
+	)
+	switch v := src.(type) {
+	case nil:
+		parsed = Decimal{}
+	case Decimal:
+		parsed = v
+	case string:
+		parsed, err = ParseDecimal(v)
+	case []byte:
+		parsed, err = ParseDecimal(string(v))
+	case float64:
+		parsed, err = NewDecimalFromFloat(v)
+	case float32:
+		parsed, err = ParseDecimal(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	default:
+		rv := reflect.ValueOf(src)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			parsed = NewDecimalFromInt(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			parsed = Decimal{unscaled: new(big.Int).SetUint64(rv.Uint())}
+		default:
+			return fmt.Errorf("typedb: cannot scan %T into Decimal", src)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, returning the decimal as a string so no precision is lost.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// MarshalJSON encodes d as a JSON string, e.g. "123.45", so JSON consumers do not round it.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes a JSON string or number. null leaves d unchanged.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	parsed, err := ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// comparableDecimal returns a value for partial update comparison that is equal for numerically
+// equal decimals regardless of scale. ok is false for non-Decimal fields.
+func comparableDecimal(v reflect.Value) (comparable reflect.Value, ok bool) {
+	switch {
+	case v.Type() == decimalType:
+		d, _ := v.Interface().(Decimal)
+		return reflect.ValueOf(d.normalized().String()), true
+	case v.Kind() == reflect.Ptr && v.Type().Elem() == decimalType:
+		if v.IsNil() {
+			return reflect.ValueOf((*string)(nil)), true
+		}
+		d, _ := v.Elem().Interface().(Decimal)
+		s := d.normalized().String()
+		return reflect.ValueOf(&s), true
+	default:
+		return reflect.Value{}, false
+	}
+}
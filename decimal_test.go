@@ -0,0 +1,260 @@
+package typedb
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// DecimalTestAccount is a test model with decimal columns
+type DecimalTestAccount struct {
+	Model
+	Limit   *Decimal `db:"credit_limit"`
+	Balance Decimal  `db:"balance"`
+	Name    string   `db:"name"`
+	ID      int64    `db:"id" load:"primary"`
+}
+
+func (a *DecimalTestAccount) TableName() string {
+	return "accounts"
+}
+
+func (a *DecimalTestAccount) QueryByID() string {
+	return "SELECT id, name, balance, credit_limit FROM accounts WHERE id = ?"
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		scale int32
+	}{
+		{input: "0", want: "0", scale: 0},
+		{input: "123.45", want: "123.45", scale: 2},
+		{input: "-0.050", want: "-0.050", scale: 3},
+		{input: "+7", want: "7", scale: 0},
+		{input: ".5", want: "0.5", scale: 1},
+		{input: "-.5", want: "-0.5", scale: 1},
+		{input: "1.5e-3", want: "0.0015", scale: 4},
+		{input: "1.5E+2", want: "150", scale: 0},
+		{input: "  42.10 ", want: "42.10", scale: 2},
+		{input: "123456789012345678901234567890.123456789", want: "123456789012345678901234567890.123456789", scale: 9},
+	}
+
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.input)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if d.String() != tt.want || d.Scale() != tt.scale {
+			t.Errorf("ParseDecimal(%q) = %s (scale %d), want %s (scale %d)", tt.input, d, d.Scale(), tt.want, tt.scale)
+		}
+	}
+
+	for _, input := range []string{"", "-", ".", "abc", "1.2.3", "1-2", "1e", "--1", "1_000"} {
+		if _, err := ParseDecimal(input); err == nil {
+			t.Errorf("ParseDecimal(%q) expected error", input)
+		}
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a := MustParseDecimal("0.1")
+	b := MustParseDecimal("0.20")
+
+	if got := a.Add(b).String(); got != "0.30" {
+		t.Errorf("0.1 + 0.20 = %s, want 0.30", got)
+	}
+	if got := a.Sub(b).String(); got != "-0.10" {
+		t.Errorf("0.1 - 0.20 = %s, want -0.10", got)
+	}
+	if got := a.Mul(b).String(); got != "0.020" {
+		t.Errorf("0.1 * 0.20 = %s, want 0.020", got)
+	}
+	if got := a.Neg().String(); got != "-0.1" {
+		t.Errorf("-0.1 = %s", got)
+	}
+	if !MustParseDecimal("1.5").Equal(MustParseDecimal("1.500")) || a.Cmp(b) != -1 || b.Cmp(a) != 1 {
+		t.Error("Expected numeric comparison regardless of scale")
+	}
+	if !(Decimal{}).IsZero() || (Decimal{}).String() != "0" || NewDecimal(12345, 2).String() != "123.45" || NewDecimal(5, -2).String() != "500" {
+		t.Error("Unexpected constructor results")
+	}
+	if f, exact := MustParseDecimal("0.5").Float64(); f != 0.5 || !exact {
+		t.Errorf("Float64() = %v, %v", f, exact)
+	}
+	if a.Unscaled().Int64() != 1 {
+		t.Error("Expected unscaled value 1")
+	}
+}
+
+func TestDecimal_Scan(t *testing.T) {
+	tests := []struct {
+		src  any
+		want string
+	}{
+		{src: "99999999999999999999.99", want: "99999999999999999999.99"},
+		{src: []byte("-1.10"), want: "-1.10"},
+		{src: int64(42), want: "42"},
+		{src: uint64(math.MaxUint64), want: "18446744073709551615"},
+		{src: 0.1, want: "0.1"},
+		{src: float32(2.5), want: "2.5"},
+		{src: nil, want: "0"},
+	}
+
+	for _, tt := range tests {
+		d := MustParseDecimal("7")
+		if err := d.Scan(tt.src); err != nil {
+			t.Errorf("Scan(%v) failed: %v", tt.src, err)
+			continue
+		}
+		if d.String() != tt.want {
+			t.Errorf("Scan(%v) = %s, want %s", tt.src, d, tt.want)
+		}
+	}
+
+	var d Decimal
+	if err := d.Scan(math.NaN()); err == nil {
+		t.Error("Expected NaN error")
+	}
+	if err := d.Scan(true); err == nil {
+		t.Error("Expected unsupported type error")
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	account := DecimalTestAccount{Balance: MustParseDecimal("10.50")}
+	data, err := json.Marshal(account)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if want := `{"Limit":null,"Balance":"10.50","Name":"","ID":0}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var decoded struct {
+		A Decimal
+		B Decimal
+		C *Decimal
+	}
+	if err := json.Unmarshal([]byte(`{"A":"1.25","B":0.1,"C":null}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.A.String() != "1.25" || decoded.B.String() != "0.1" || decoded.C != nil {
+		t.Errorf("Unexpected decoded values %+v", decoded)
+	}
+}
+
+func TestDecimal_DeserializeAndSerialize(t *testing.T) {
+	account, err := deserializeForType[*DecimalTestAccount](map[string]any{
+		"balance":      "12345678901234567890.12",
+		"credit_limit": float64(2500),
+	})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if account.Balance.String() != "12345678901234567890.12" || account.Limit == nil || account.Limit.String() != "2500" {
+		t.Errorf("Unexpected account %+v", account)
+	}
+
+	account, err = deserializeForType[*DecimalTestAccount](map[string]any{"credit_limit": nil})
+	if err != nil || account.Limit != nil {
+		t.Errorf("Expected NULL limit to stay nil, got %v, %v", account.Limit, err)
+	}
+
+	columns, values, _, err := serializeModelFields(&DecimalTestAccount{Balance: MustParseDecimal("0.10")}, "ID")
+	if err != nil {
+		t.Fatalf("serializeModelFields failed: %v", err)
+	}
+	if !reflect.DeepEqual(columns, []string{"balance"}) || values[0] != "0.10" {
+		t.Errorf("Expected balance as exact text, got %v %v", columns, values)
+	}
+}
+
+func TestDecimal_PartialUpdateComparesNumerically(t *testing.T) {
+	withModelOptions(t, reflect.TypeOf(DecimalTestAccount{}), ModelOptions{PartialUpdate: true})
+
+	account, err := deserializeForType[*DecimalTestAccount](map[string]any{"id": int64(1), "balance": "1.50", "credit_limit": "100"})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+
+	account.Balance = MustParseDecimal("1.5")
+	limit := MustParseDecimal("100.00")
+	account.Limit = &limit
+	changed, err := getChangedFields(account, "ID")
+	if err != nil {
+		t.Fatalf("getChangedFields failed: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("Expected numerically equal decimals to be unchanged, got %v", changed)
+	}
+
+	account.Balance = MustParseDecimal("1.51")
+	account.Limit = nil
+	changed, err = getChangedFields(account, "ID")
+	if err != nil {
+		t.Fatalf("getChangedFields failed: %v", err)
+	}
+	if !reflect.DeepEqual(changed, map[string]bool{"balance": true, "credit_limit": true}) {
+		t.Errorf("Expected balance and credit_limit to change, got %v", changed)
+	}
+}
+
+func TestDecimal_ZeroOmittedOnInsert(t *testing.T) {
+	for _, balance := range []Decimal{{}, MustParseDecimal("0"), MustParseDecimal("0.00"), NewDecimal(0, 4)} {
+		var captured string
+		mock := &MockExecutor{
+			QueryRowMapFunc: func(ctx context.Context, query string, args ...any) (map[string]any, error) {
+				captured = query
+				return map[string]any{"id": int64(1)}, nil
+			},
+		}
+
+		account := &DecimalTestAccount{Name: "Alice", Balance: balance}
+		if err := Insert(context.Background(), mock, account); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		if strings.Contains(captured, "balance") {
+			t.Errorf("Expected zero balance %q to be omitted like other zero values, got %s", balance.String(), captured)
+		}
+	}
+
+	if isZeroOrNil(reflect.ValueOf(MustParseDecimal("0.01"))) {
+		t.Error("Expected non-zero decimal not to be zero")
+	}
+}
+
+func TestDecimal_SQLiteRoundTrip(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE accounts (id INTEGER PRIMARY KEY, name TEXT, balance TEXT, credit_limit NUMERIC)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	limit := NewDecimalFromInt(1000)
+	account := &DecimalTestAccount{Name: "Alice", Balance: MustParseDecimal("98765432109876543210.0001"), Limit: &limit}
+	if err := Insert(ctx, db, account); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	loaded := &DecimalTestAccount{ID: account.ID}
+	if err := Load(ctx, db, loaded); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Balance.String() != "98765432109876543210.0001" {
+		t.Errorf("Expected exact balance, got %s", loaded.Balance)
+	}
+	if loaded.Limit == nil || !loaded.Limit.Equal(limit) {
+		t.Errorf("Expected limit 1000, got %v", loaded.Limit)
+	}
+}
@@ -0,0 +1,348 @@
+package typedb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// deleteKey is a column and value identifying the rows to delete.
+type deleteKey struct {
+	column string
+	value  any
+	nolog  bool
+}
+
+// Delete deletes a model from the database by its load:"primary" field.
+// The model must:
+//   - Implement TableName() method that returns the table name
+//   - Have a field with load:"primary" tag, set to a non-zero value
+//   - Not have dot notation in db tags (simple model, not joined)
+//
+// Returns ErrNotFound if no row was deleted.
+//
+// Models with a dbDelete:"soft-timestamp" or dbDelete:"soft-flag" field are soft-deleted instead:
+// the column is set to the current timestamp or true on rows not deleted yet, so deleting a
+// soft-deleted row returns ErrNotFound. This applies to all delete functions; use WithHardDelete
+// to remove the rows, and Restore to undo a soft delete.
+//
+// Models implementing BeforeDeleteHook or AfterDeleteHook have them called before and after the delete.
+//
+// Example:
+//
+//	user := &User{ID: 123}
+//	err := typedb.Delete(ctx, db, user)
+//	// Generates: DELETE FROM users WHERE id = $1
+func Delete[T ModelInterface](ctx context.Context, exec Executor, model T) error {
+	_, primaryField, _, err := validateDeleteModel(model)
+	if err != nil {
+		return err
+	}
+
+	key, err := deleteKeyForField(exec, model, primaryField)
+	if err != nil {
+		return err
+	}
+	return deleteModel(ctx, exec, model, []deleteKey{key})
+}
+
+// DeleteByField deletes the rows whose column matches the value of the named field of the model.
+// The field must have a db tag and a non-zero value. Every matching row is deleted, so the field
+// is typically tagged load:"unique".
+// Returns ErrNotFound if no row was deleted.
+//
+// Example:
+//
+//	user := &User{Email: "john@example.com"}
+//	err := typedb.DeleteByField(ctx, db, user, "Email")
+func DeleteByField[T ModelInterface](ctx context.Context, exec Executor, model T, fieldName string) error {
+	modelType := getModelType(model)
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	field, found := modelType.FieldByName(fieldName)
+	if !found {
+		return fmt.Errorf("%w: %s", ErrFieldNotFound, fieldName)
+	}
+
+	key, err := deleteKeyForField(exec, model, &field)
+	if err != nil {
+		return err
+	}
+	return deleteModel(ctx, exec, model, []deleteKey{key})
+}
+
+// DeleteByComposite deletes the rows matching a composite key of the model.
+// The model must have at least 2 fields with load:"composite:name" tags, all set to non-zero values.
+// Returns ErrNotFound if no row was deleted.
+//
+// Example:
+//
+//	userPost := &UserPost{UserID: 1, PostID: 2}
+//	err := typedb.DeleteByComposite(ctx, db, userPost, "userpost")
+//	// Generates: DELETE FROM user_posts WHERE post_id = $1 AND user_id = $2
+func DeleteByComposite[T ModelInterface](ctx context.Context, exec Executor, model T, compositeName string) error {
+	t := getModelType(model)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var compositeFields []*reflect.StructField
+	collectCompositeFields(t, compositeName, &compositeFields)
+	if len(compositeFields) < 2 {
+		return fmt.Errorf("typedb: composite key %q must have at least 2 fields", compositeName)
+	}
+
+	// Sort fields by name, matching the argument order of LoadByComposite
+	sort.Slice(compositeFields, func(i, j int) bool {
+		return compositeFields[i].Name < compositeFields[j].Name
+	})
+
+	keys := make([]deleteKey, len(compositeFields))
+	for i, field := range compositeFields {
+		key, err := deleteKeyForField(exec, model, field)
+		if err != nil {
+			return err
+		}
+		keys[i] = key
+	}
+	return deleteModel(ctx, exec, model, keys)
+}
+
+// DeleteMany deletes models by their load:"primary" field with DELETE FROM table WHERE pk IN (...).
+// Keys beyond the driver's bind parameter limit (999 on SQLite, 2099 on SQL Server) are split across
+// statements, run in one transaction when exec is a *DB. All models must have their primary key set.
+// Does nothing for an empty slice. Returns ErrNotFound if no row was deleted;
+// deleting only some of the models is not an error.
+// BeforeDeleteHook and AfterDeleteHook are called for every model, before and after the statements.
+//
+// Example:
+//
+//	err := typedb.DeleteMany(ctx, db, []*User{{ID: 1}, {ID: 2}, {ID: 3}})
+//	// Generates: DELETE FROM users WHERE id IN ($1, $2, $3)
+func DeleteMany[T ModelInterface](ctx context.Context, exec Executor, models []T) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	tableName, primaryField, primaryKeyColumn, err := validateDeleteModel(models[0])
+	if err != nil {
+		return err
+	}
+
+	args := make([]any, len(models))
+	var maskIndices []int
+	for i, model := range models {
+		key, err := deleteKeyForField(exec, model, primaryField)
+		if err != nil {
+			return fmt.Errorf("typedb: DeleteMany model %d: %w", i, err)
+		}
+		args[i] = key.value
+		if key.nolog {
+			maskIndices = append(maskIndices, i)
+		}
+	}
+
+	for _, model := range models {
+		if err := runBeforeDelete(ctx, exec, model); err != nil {
+			return err
+		}
+	}
+
+	deleteChunks := func(exec Executor) error {
+		return deleteManyChunks(ctx, exec, models[0], tableName, primaryKeyColumn, args, maskIndices)
+	}
+	var deleteErr error
+	if db, ok := exec.(*DB); ok && len(args) > maxBindParameters(db.driverName) {
+		// Several statements: delete all models or none
+		deleteErr = db.WithTx(ctx, func(tx *Tx) error { return deleteChunks(tx) }, nil)
+	} else {
+		deleteErr = deleteChunks(exec)
+	}
+	if deleteErr != nil {
+		return deleteErr
+	}
+
+	for _, model := range models {
+		if err := runAfterDelete(ctx, exec, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteManyChunks deletes the rows keyed by args with one IN statement per bind parameter limit.
+// maskIndices are indices into args. Returns ErrNotFound if no statement deleted a row.
+func deleteManyChunks(ctx context.Context, exec Executor, model ModelInterface, tableName, primaryKeyColumn string, args []any, maskIndices []int) error {
+	driverName := getDriverName(exec)
+	chunkSize := maxBindParameters(driverName)
+	var deleted int64
+	for start := 0; start < len(args); start += chunkSize {
+		end := start + chunkSize
+		if end > len(args) {
+			end = len(args)
+		}
+
+		placeholders := make([]string, end-start)
+		for i := range placeholders {
+			placeholders[i] = generatePlaceholder(driverName, i+1)
+		}
+		query := deleteStatement(ctx, driverName, model, tableName, fmt.Sprintf("%s IN (%s)",
+			quoteIdentifier(driverName, primaryKeyColumn),
+			strings.Join(placeholders, ", ")))
+
+		chunkCtx := ctx
+		var chunkMask []int
+		for _, index := range maskIndices {
+			if index >= start && index < end {
+				chunkMask = append(chunkMask, index-start)
+			}
+		}
+		if len(chunkMask) > 0 {
+			chunkCtx = WithMaskIndices(ctx, chunkMask)
+		}
+
+		affected, err := execDeleteCount(chunkCtx, exec, query, args[start:end])
+		if err != nil {
+			return err
+		}
+		deleted += affected
+	}
+
+	if deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// validateDeleteModel checks the model can be deleted by primary key and returns its table,
+// primary key field and primary key column.
+func validateDeleteModel(model ModelInterface) (tableName string, primaryField *reflect.StructField, primaryKeyColumn string, err error) {
+	tableName, err = getTableName(model)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("typedb: Delete validation failed: %w", err)
+	}
+
+	if hasDotNotation(model) {
+		return "", nil, "", fmt.Errorf("typedb: Delete cannot be used with joined models (detected dot notation in db tags)")
+	}
+
+	primaryField, found := findFieldByTag(model, "load", "primary")
+	if !found {
+		return "", nil, "", fmt.Errorf("typedb: Delete requires a field with load:\"primary\" tag")
+	}
+
+	primaryKeyColumn = primaryField.Tag.Get("db")
+	if primaryKeyColumn == "" || primaryKeyColumn == "-" {
+		return "", nil, "", fmt.Errorf("typedb: primary key field %s must have a db tag", primaryField.Name)
+	}
+
+	return tableName, primaryField, primaryKeyColumn, nil
+}
+
+// deleteKeyForField returns the column and serialized value of a key field, which must be set.
+func deleteKeyForField(exec Executor, model ModelInterface, field *reflect.StructField) (deleteKey, error) {
+	column := field.Tag.Get("db")
+	if column == "" || column == "-" {
+		return deleteKey{}, fmt.Errorf("typedb: field %s must have a db tag", field.Name)
+	}
+
+	valueReflect, err := getFieldValue(model, field.Name)
+	if err != nil {
+		return deleteKey{}, fmt.Errorf("typedb: failed to get field %s value: %w", field.Name, err)
+	}
+	if valueReflect.IsZero() {
+		return deleteKey{}, fmt.Errorf("typedb: field %s is not set", field.Name)
+	}
+
+	value, err := serializeFieldValue(valueReflect, newSerializeOptions(exec))
+	if err != nil {
+		return deleteKey{}, fmt.Errorf("typedb: failed to serialize field %s value: %w", field.Name, err)
+	}
+
+	return deleteKey{column: column, value: value, nolog: field.Tag.Get("nolog") == "true"}, nil
+}
+
+// deleteModel deletes the rows of the model's table matching all keys, running the model's delete hooks.
+func deleteModel(ctx context.Context, exec Executor, model ModelInterface, keys []deleteKey) error {
+	tableName, err := getTableName(model)
+	if err != nil {
+		return fmt.Errorf("typedb: Delete validation failed: %w", err)
+	}
+	if hasDotNotation(model) {
+		return fmt.Errorf("typedb: Delete cannot be used with joined models (detected dot notation in db tags)")
+	}
+
+	if err := runBeforeDelete(ctx, exec, model); err != nil {
+		return err
+	}
+
+	driverName := getDriverName(exec)
+	conditions := make([]string, len(keys))
+	args := make([]any, len(keys))
+	var maskIndices []int
+	for i, key := range keys {
+		conditions[i] = fmt.Sprintf("%s = %s", quoteIdentifier(driverName, key.column), generatePlaceholder(driverName, i+1))
+		args[i] = key.value
+		if key.nolog {
+			maskIndices = append(maskIndices, i)
+		}
+	}
+	query := deleteStatement(ctx, driverName, model, tableName, strings.Join(conditions, " AND "))
+
+	if len(maskIndices) > 0 {
+		ctx = WithMaskIndices(ctx, maskIndices)
+	}
+	if err := execDelete(ctx, exec, query, args); err != nil {
+		return err
+	}
+
+	return runAfterDelete(ctx, exec, model)
+}
+
+// deleteStatement returns the statement deleting the rows of tableName matching where.
+// Models with a dbDelete field are soft-deleted with an UPDATE of the rows not deleted yet,
+// unless WithHardDelete was set on the context.
+func deleteStatement(ctx context.Context, driverName string, model ModelInterface, tableName, where string) string {
+	column, ok := findSoftDeleteColumn(model)
+	if !ok || isHardDelete(ctx) {
+		return fmt.Sprintf("DELETE FROM %s WHERE %s", quoteIdentifier(driverName, tableName), where)
+	}
+	return fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s AND %s",
+		quoteIdentifier(driverName, tableName),
+		quoteIdentifier(driverName, column.name),
+		column.deletedValue(driverName),
+		where,
+		column.notDeletedCondition(driverName))
+}
+
+// execDelete executes a DELETE statement, returning ErrNotFound if it affected no rows.
+func execDelete(ctx context.Context, exec Executor, query string, args []any) error {
+	affected, err := execDeleteCount(ctx, exec, query, args)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// execDeleteCount executes a DELETE statement and returns the number of rows it affected.
+func execDeleteCount(ctx context.Context, exec Executor, query string, args []any) (int64, error) {
+	result, err := exec.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("typedb: Delete failed: %w", err)
+	}
+	if result == nil {
+		return 0, fmt.Errorf("typedb: Delete returned nil result")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("typedb: Delete failed to get rows affected: %w", err)
+	}
+	return affected, nil
+}
@@ -0,0 +1,296 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// DeleteTestUser is a test model for Delete
+type DeleteTestUser struct {
+	Model
+	Email   string   `db:"email" load:"unique"`
+	Token   string   `db:"token" nolog:"true"`
+	Deleted []string `db:"-"`
+	ID      int64    `db:"id" load:"primary"`
+}
+
+func (u *DeleteTestUser) TableName() string {
+	return "users"
+}
+
+func (u *DeleteTestUser) QueryByID() string {
+	return "SELECT id, email, token FROM users WHERE id = ?"
+}
+
+func (u *DeleteTestUser) QueryByEmail() string {
+	return "SELECT id, email, token FROM users WHERE email = ?"
+}
+
+func (u *DeleteTestUser) BeforeDelete(ctx context.Context, exec Executor) error {
+	if u.Email == "protected@example.com" {
+		return errors.New("protected user")
+	}
+	u.Deleted = append(u.Deleted, "before")
+	return nil
+}
+
+func (u *DeleteTestUser) AfterDelete(ctx context.Context, exec Executor) error {
+	u.Deleted = append(u.Deleted, "after")
+	return nil
+}
+
+// DeleteTestMembership is a test model with a composite key
+type DeleteTestMembership struct {
+	Model
+	Role    string `db:"role"`
+	GroupID int64  `db:"group_id" load:"composite:membership"`
+	UserID  int64  `db:"user_id" load:"composite:membership" nolog:"true"`
+}
+
+func (m *DeleteTestMembership) TableName() string {
+	return "memberships"
+}
+
+func (m *DeleteTestMembership) QueryByGroupIDUserID() string {
+	return "SELECT group_id, user_id, role FROM memberships WHERE group_id = ? AND user_id = ?"
+}
+
+func TestDelete_SQLite(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT, token TEXT)",
+		"CREATE TABLE memberships (group_id INTEGER, user_id INTEGER, role TEXT)",
+		"INSERT INTO users (id, email, token) VALUES (1, 'a@example.com', 't1'), (2, 'b@example.com', 't2'), (3, 'c@example.com', 't3'), (4, 'd@example.com', 't4'), (5, 'protected@example.com', 't5')",
+		"INSERT INTO memberships (group_id, user_id, role) VALUES (10, 1, 'owner'), (10, 2, 'member')",
+	} {
+		if _, err := db.Exec(ctx, stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+
+	user := &DeleteTestUser{ID: 1}
+	if err := Delete(ctx, db, user); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if strings.Join(user.Deleted, ",") != "before,after" {
+		t.Errorf("Expected delete hooks to run, got %v", user.Deleted)
+	}
+	if err := Load(ctx, db, &DeleteTestUser{ID: 1}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected deleted user to be gone, got %v", err)
+	}
+	if err := Delete(ctx, db, &DeleteTestUser{ID: 1}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing row, got %v", err)
+	}
+
+	if err := DeleteByField(ctx, db, &DeleteTestUser{Email: "b@example.com"}, "Email"); err != nil {
+		t.Errorf("DeleteByField failed: %v", err)
+	}
+	if err := DeleteByField(ctx, db, &DeleteTestUser{}, "Missing"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("Expected ErrFieldNotFound, got %v", err)
+	}
+	if err := DeleteByField(ctx, db, &DeleteTestUser{Email: "protected@example.com"}, "Email"); err == nil || !strings.Contains(err.Error(), "protected user") {
+		t.Errorf("Expected BeforeDelete to abort, got %v", err)
+	}
+
+	if err := DeleteByComposite(ctx, db, &DeleteTestMembership{GroupID: 10, UserID: 2}, "membership"); err != nil {
+		t.Errorf("DeleteByComposite failed: %v", err)
+	}
+	if err := DeleteByComposite(ctx, db, &DeleteTestMembership{GroupID: 10}, "membership"); err == nil || !strings.Contains(err.Error(), "UserID is not set") {
+		t.Errorf("Expected unset composite field error, got %v", err)
+	}
+
+	if err := DeleteMany(ctx, db, []*DeleteTestUser{{ID: 3}, {ID: 4}, {ID: 99}}); err != nil {
+		t.Errorf("DeleteMany failed: %v", err)
+	}
+	if err := DeleteMany(ctx, db, []*DeleteTestUser{{ID: 98}, {ID: 99}}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound when nothing was deleted, got %v", err)
+	}
+	if err := DeleteMany(ctx, db, []*DeleteTestUser{}); err != nil {
+		t.Errorf("Expected empty DeleteMany to do nothing, got %v", err)
+	}
+
+	row, err := db.QueryRowMap(ctx, "SELECT COUNT(*) AS n FROM users")
+	if err != nil || row["n"] != int64(1) {
+		t.Errorf("Expected only the protected user to remain, got %v, %v", row, err)
+	}
+}
+
+func TestDelete_QueriesPerDriver(t *testing.T) {
+	tests := []struct {
+		driver string
+		query  string
+	}{
+		{driver: "postgres", query: `DELETE FROM "users" WHERE "id" IN ($1, $2)`},
+		{driver: "mysql", query: "DELETE FROM `users` WHERE `id` IN (?, ?)"},
+		{driver: "sqlserver", query: "DELETE FROM [users] WHERE [id] IN (@p1, @p2)"},
+		{driver: "oracle", query: `DELETE FROM "USERS" WHERE "ID" IN (:1, :2)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock: %v", err)
+			}
+			defer sqlDB.Close()
+
+			db := NewDB(sqlDB, tt.driver, 5*time.Second)
+			mock.ExpectExec(regexp.QuoteMeta(tt.query)).WithArgs(int64(1), int64(2)).WillReturnResult(sqlmock.NewResult(0, 2))
+			if err := DeleteMany(context.Background(), db, []*DeleteTestUser{{ID: 1}, {ID: 2}}); err != nil {
+				t.Errorf("DeleteMany failed: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeleteMany_ChunksKeysInTransaction(t *testing.T) {
+	// SQLite allows 999 parameters per statement: 1000 keys take two statements in one transaction
+	users := make([]*DeleteTestUser, 1000)
+	for i := range users {
+		users[i] = &DeleteTestUser{ID: int64(i + 1)}
+	}
+
+	t.Run("commits", func(t *testing.T) {
+		sqlDB, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock: %v", err)
+		}
+		defer sqlDB.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "users" WHERE "id" IN \(\?(, \?){998}\)$`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE "id" IN (?)`)).WithArgs(int64(1000)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		db := NewDB(sqlDB, "sqlite3", 5*time.Second)
+		if err := DeleteMany(context.Background(), db, users); err != nil {
+			t.Fatalf("DeleteMany failed: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("rolls back when nothing is deleted", func(t *testing.T) {
+		sqlDB, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock: %v", err)
+		}
+		defer sqlDB.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		db := NewDB(sqlDB, "sqlite3", 5*time.Second)
+		if err := DeleteMany(context.Background(), db, users); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Expected ErrNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+}
+
+func TestDeleteMany_SQLServerParameterLimit(t *testing.T) {
+	// SQL Server statements take at most 2099 parameters: 2099 keys fit in one statement, 2100 take two
+	placeholders := func(n int) string {
+		list := make([]string, n)
+		for i := range list {
+			list[i] = fmt.Sprintf("@p%d", i+1)
+		}
+		return strings.Join(list, ", ")
+	}
+	users := func(n int) []*DeleteTestUser {
+		models := make([]*DeleteTestUser, n)
+		for i := range models {
+			models[i] = &DeleteTestUser{ID: int64(i + 1)}
+		}
+		return models
+	}
+
+	t.Run("at the limit", func(t *testing.T) {
+		sqlDB, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock: %v", err)
+		}
+		defer sqlDB.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM [users] WHERE [id] IN ("+placeholders(2099)+")") + "$").WillReturnResult(sqlmock.NewResult(0, 2099))
+
+		db := NewDB(sqlDB, "sqlserver", 5*time.Second)
+		if err := DeleteMany(context.Background(), db, users(2099)); err != nil {
+			t.Fatalf("DeleteMany failed: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("one over the limit", func(t *testing.T) {
+		sqlDB, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock: %v", err)
+		}
+		defer sqlDB.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM [users] WHERE [id] IN ("+placeholders(2099)+")") + "$").WillReturnResult(sqlmock.NewResult(0, 2099))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM [users] WHERE [id] IN (@p1)") + "$").WithArgs(int64(2100)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		db := NewDB(sqlDB, "sqlserver", 5*time.Second)
+		if err := DeleteMany(context.Background(), db, users(2100)); err != nil {
+			t.Fatalf("DeleteMany failed: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+}
+
+func TestDelete_MasksNologArgs(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	logger := &testLogger{}
+	db := NewDBWithLogger(sqlDB, "postgres", 5*time.Second, logger)
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "memberships" WHERE "group_id" = $1 AND "user_id" = $2`)).
+		WithArgs(int64(10), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE "token" = $1`)).
+		WithArgs("secret").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ctx := context.Background()
+	if err := DeleteByComposite(ctx, db, &DeleteTestMembership{GroupID: 10, UserID: 7}, "membership"); err != nil {
+		t.Fatalf("DeleteByComposite failed: %v", err)
+	}
+	if err := DeleteByField(ctx, db, &DeleteTestUser{Token: "secret"}, "Token"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	logged := fmt.Sprint(logger.debugs)
+	if strings.Contains(logged, "secret") || strings.Contains(logged, " 7]") || !strings.Contains(logged, "[REDACTED]") {
+		t.Errorf("Expected nolog key values to be masked, got %s", logged)
+	}
+}
@@ -1,6 +1,9 @@
 package typedb
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -11,11 +14,49 @@ import (
 	"unsafe"
 )
 
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// deserializeOptions carries DB-level and per-call settings that affect deserialization.
+// The zero value applies model-level settings only.
+type deserializeOptions struct {
+	codecs              *codecRegistry // From WithCodec / RegisterDBCodec
+	times               timeSettings   // From WithTimeLocation / WithTimeNormalization
+	contextColumnPolicy ColumnPolicy   // From WithColumnPolicyOverride
+	logger              Logger         // For column policy warnings
+	dbColumnPolicy      ColumnPolicy   // From WithColumnPolicy on the DB
+	preserveColumnCase  bool           // From WithPreserveColumnCase
+
+	afterLoad func(ModelInterface) error // Runs AfterLoad hooks in the query's executor; nil skips them
+}
+
+// newDeserializeOptions resolves deserialization settings from the context and executor.
+func newDeserializeOptions(ctx context.Context, exec Executor) deserializeOptions {
+	return deserializeOptions{
+		codecs:              getExecutorCodecs(exec),
+		times:               getExecutorTimeSettings(exec),
+		contextColumnPolicy: getColumnPolicyOverride(ctx),
+		dbColumnPolicy:      getExecutorColumnPolicy(exec),
+		logger:              getExecutorLogger(exec),
+		preserveColumnCase:  getExecutorPreserveColumnCase(exec),
+		afterLoad: func(model ModelInterface) error {
+			return runAfterLoad(ctx, exec, model)
+		},
+	}
+}
+
 // deserializeForType creates a new instance of T and deserializes the row into it.
 // Returns a pointer to the deserialized model.
 // T must be a pointer type (e.g., *User).
 // This is an internal function - users should deserialize via Query, InsertAndLoad, etc.
 func deserializeForType[T ModelInterface](row map[string]any) (T, error) {
+	return deserializeForTypeWithOptions[T](row, deserializeOptions{})
+}
+
+// deserializeForTypeWithOptions is deserializeForType with DB-level and per-call settings applied.
+func deserializeForTypeWithOptions[T ModelInterface](row map[string]any, opts deserializeOptions) (T, error) {
 	var model T
 	modelType := reflect.TypeOf(model)
 	if modelType.Kind() != reflect.Ptr {
@@ -31,7 +72,7 @@ func deserializeForType[T ModelInterface](row map[string]any) (T, error) {
 		return zero, fmt.Errorf("typedb: type %T does not implement ModelInterface", modelPtr.Interface())
 	}
 
-	if err := deserialize(row, modelInterface); err != nil {
+	if err := deserializeWithOptions(row, modelInterface, opts); err != nil {
 		var zero T
 		return zero, err
 	}
@@ -49,6 +90,11 @@ func deserializeForType[T ModelInterface](row map[string]any) (T, error) {
 // Uses reflection to map database column names (from db tags) to struct fields.
 // This is an internal function - users should deserialize via Query, InsertAndLoad, etc.
 func deserialize(row map[string]any, dest ModelInterface) error {
+	return deserializeWithOptions(row, dest, deserializeOptions{})
+}
+
+// deserializeWithOptions is deserialize with DB-level and per-call settings applied.
+func deserializeWithOptions(row map[string]any, dest ModelInterface, opts deserializeOptions) error {
 	destValue := reflect.ValueOf(dest)
 	if destValue.Kind() != reflect.Ptr {
 		return fmt.Errorf("typedb: dest must be a pointer type")
@@ -62,28 +108,106 @@ func deserialize(row map[string]any, dest ModelInterface) error {
 		return fmt.Errorf("typedb: dest must be a pointer to struct")
 	}
 
+	fieldMap, policyRow, unresolved, err := deserializeStructFields(row, destValue, opts)
+	if err != nil {
+		return err
+	}
+
+	policy := resolveColumnPolicy(opts.contextColumnPolicy, GetModelOptions(structValue.Type()).ColumnPolicy, opts.dbColumnPolicy)
+	if err := checkDuplicateColumns(policy, unresolved, opts.logger); err != nil {
+		return fmt.Errorf("typedb: %s: %w", structValue.Type().Name(), err)
+	}
+	if err := applyColumnPolicy(policy, policyRow, fieldMap); err != nil {
+		return fmt.Errorf("typedb: %s: %w", structValue.Type().Name(), err)
+	}
+
+	// Save original copy if partial update is enabled for this model
+	if err := saveOriginalCopyIfEnabled(dest); err != nil {
+		return fmt.Errorf("typedb: failed to save original copy: %w", err)
+	}
+
+	if opts.afterLoad != nil {
+		return opts.afterLoad(dest)
+	}
+
+	return nil
+}
+
+// deserializeStructFields deserializes the columns of row into the struct pointed to by ptrValue,
+// including nested struct fields. It returns the field map, the row to use for column policy checks
+// and the repeated columns that could not be mapped to a field.
+func deserializeStructFields(row map[string]any, ptrValue reflect.Value, opts deserializeOptions) (fieldMap map[string]reflect.Value, policyRow map[string]any, unresolved []string, err error) {
+	structValue := ptrValue.Elem()
+
 	// buildFieldMapFromPtr bypasses checkptr; reflect.NewAt + Field() can trigger errors.
-	fieldMap := buildFieldMapFromPtr(destValue, structValue)
+	fieldMap = buildFieldMapFromPtr(ptrValue, structValue)
+	jsonFields := jsonColumns(structValue.Type())
+	timeFormats := timeFormatColumns(structValue.Type())
+	row, unresolved = resolveColumnNames(row, structValue.Type(), opts.preserveColumnCase)
+	policyRow, err = hydrateNestedStructs(row, structValue.Type(), fieldMap, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	for key, value := range row {
-		if value == nil {
+		if key == collectColumnsTag {
 			continue
 		}
 
 		if fieldValue, ok := fieldMap[key]; ok {
-			// Work directly with reflect.Value instead of converting to interface
-			// This avoids issues with reflect.NewAt pointers losing type information
-			if err := deserializeToFieldValue(fieldValue, value); err != nil {
-				return fmt.Errorf("field %s: %w", key, err)
+			// dbType:"json" fields are always decoded as JSON
+			if jsonFields[key] {
+				if err := decodeJSONColumn(fieldValue, key, value); err != nil {
+					return nil, nil, nil, err
+				}
+				continue
+			}
+
+			// Null[T] fields record NULL, and decode other values into V as for a T field
+			if target, ok := fieldValue.Interface().(nullTarget); ok {
+				if value == nil {
+					target.setValid(false)
+					continue
+				}
+				if err := deserializeColumn(target.valueTarget(), key, value, timeFormats[key], opts); err != nil {
+					return nil, nil, nil, err
+				}
+				target.setValid(true)
+				continue
+			}
+
+			if err := deserializeColumn(fieldValue, key, value, timeFormats[key], opts); err != nil {
+				return nil, nil, nil, err
 			}
 		}
 	}
 
-	// Save original copy if partial update is enabled for this model
-	if err := saveOriginalCopyIfEnabled(dest); err != nil {
-		return fmt.Errorf("typedb: failed to save original copy: %w", err)
+	return fieldMap, policyRow, unresolved, nil
+}
+
+// deserializeColumn decodes a column value into the field pointed to by fieldValue.
+func deserializeColumn(fieldValue reflect.Value, key string, value any, timeFormat string, opts deserializeOptions) error {
+	// Registered codecs take precedence over all built-in handling
+	if err := decodeWithRegisteredCodec(fieldValue, value, opts.codecs); err != errNotMyType {
+		if err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
+		}
+		return nil
 	}
 
+	// time.Time and time.Duration fields honor timeFormat tags and DB time settings
+	if err := decodeTimeColumn(fieldValue, value, timeFormat, opts.times); err != errNotMyType {
+		if err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
+		}
+		return nil
+	}
+
+	// Work directly with reflect.Value instead of converting to interface
+	// This avoids issues with reflect.NewAt pointers losing type information
+	if err := deserializeToFieldValue(fieldValue, value); err != nil {
+		return fmt.Errorf("field %s: %w", key, err)
+	}
 	return nil
 }
 
@@ -462,6 +586,16 @@ func deserializeToFieldValue(fieldValuePtr reflect.Value, value any) error {
 	fieldElem := fieldValuePtr.Elem()
 	fieldType := fieldElem.Type()
 
+	// Fields implementing sql.Scanner handle their own conversion, including NULL
+	if err := deserializeScanner(fieldValuePtr, value); err != errNotMyType {
+		return err
+	}
+
+	// Built-in codecs (UUID-shaped arrays, net.IP, url.URL)
+	if err := decodeWithBuiltinCodec(fieldValuePtr, value); err != errNotMyType {
+		return err
+	}
+
 	// Handle nil values
 	if value == nil {
 		if fieldType.Kind() == reflect.Ptr {
@@ -472,6 +606,11 @@ func deserializeToFieldValue(fieldValuePtr reflect.Value, value any) error {
 		return nil
 	}
 
+	// Byte slices and arrays from binary or text columns, copied without string conversion
+	if err := decodeBinary(fieldValuePtr, value); err != errNotMyType {
+		return err
+	}
+
 	valueValue := reflect.ValueOf(value)
 
 	// Try direct assignment first
@@ -486,11 +625,47 @@ func deserializeToFieldValue(fieldValuePtr reflect.Value, value any) error {
 		return nil
 	}
 
+	// Slices and maps from PostgreSQL array literals or JSON text
+	if err := decodeCollection(fieldValuePtr, value); err != errNotMyType {
+		return err
+	}
+
 	// Use the existing DeserializeToField for type-specific handling
 	// Convert to interface for the type switch
 	return deserializeToField(fieldValuePtr.Interface(), value)
 }
 
+// deserializeScanner populates fields whose type (or pointer to it) implements sql.Scanner.
+// For *T fields where *T is a Scanner, NULL sets the field to nil and other values are scanned
+// into a newly allocated T. Returns errNotMyType for fields that are not Scanners.
+func deserializeScanner(fieldValuePtr reflect.Value, value any) error {
+	if scanner, ok := fieldValuePtr.Interface().(sql.Scanner); ok {
+		return scanner.Scan(value)
+	}
+
+	fieldElem := fieldValuePtr.Elem()
+	fieldType := fieldElem.Type()
+	if fieldType.Kind() != reflect.Ptr || !fieldType.Implements(scannerType) {
+		return errNotMyType
+	}
+
+	if value == nil {
+		fieldElem.Set(reflect.Zero(fieldType))
+		return nil
+	}
+
+	newValue := reflect.New(fieldType.Elem())
+	scanner, ok := newValue.Interface().(sql.Scanner)
+	if !ok {
+		return errNotMyType
+	}
+	if err := scanner.Scan(value); err != nil {
+		return err
+	}
+	fieldElem.Set(newValue)
+	return nil
+}
+
 // deserializeWithReflection handles complex types using reflection.
 func deserializeWithReflection(targetElem reflect.Value, value any) error {
 	valueValue := reflect.ValueOf(value)
@@ -1091,6 +1266,11 @@ func deserializeMap(value any) (map[string]string, error) {
 
 // parseTime tries to parse a string into time.Time using common formats
 func parseTime(s string) (time.Time, error) {
+	return parseTimeInLocation(s, time.UTC)
+}
+
+// parseTimeInLocation is parseTime with timestamps lacking zone information interpreted in loc.
+func parseTimeInLocation(s string, loc *time.Location) (time.Time, error) {
 	if s == "" {
 		return time.Time{}, nil
 	}
@@ -1104,10 +1284,13 @@ func parseTime(s string) (time.Time, error) {
 		"2006-01-02",
 		"2006-01-02 15:04:05.999999",
 		"2006-01-02 15:04:05.999999999",
+		// PostgreSQL timestamptz text output (e.g., inside array literals)
+		"2006-01-02 15:04:05.999999999-07",
+		"2006-01-02 15:04:05.999999999-07:00",
 	}
 
 	for _, format := range formats {
-		if t, err := time.Parse(format, s); err == nil {
+		if t, err := time.ParseInLocation(format, s, loc); err == nil {
 			return t, nil
 		}
 	}
@@ -12,6 +12,29 @@ var ErrFieldNotFound = errors.New("typedb: field not found")
 // ErrMethodNotFound is returned when a method cannot be found.
 var ErrMethodNotFound = errors.New("typedb: method not found")
 
+// ErrDuplicateKey is returned by QueryMap when two rows produce the same key.
+var ErrDuplicateKey = errors.New("typedb: duplicate key")
+
+// ErrInvalidCursor is returned by QueryPage when a cursor token is malformed, tampered with,
+// or was issued for a different key set.
+var ErrInvalidCursor = errors.New("typedb: invalid cursor")
+
+// ErrUnknownColumn is returned when a result column does not map to any db tag
+// and the ColumnPolicy includes ColumnPolicyErrorOnUnknown.
+var ErrUnknownColumn = errors.New("typedb: unknown column")
+
+// ErrMissingColumn is returned when a db-tagged field has no matching result column
+// and the ColumnPolicy includes ColumnPolicyErrorOnMissing.
+var ErrMissingColumn = errors.New("typedb: missing column")
+
+// ErrDuplicateColumn is returned when a result repeats a column name that cannot be mapped
+// to a field and the ColumnPolicy includes ColumnPolicyErrorOnDuplicate.
+var ErrDuplicateColumn = errors.New("typedb: duplicate column")
+
+// ErrUnknownDiscriminator is returned by QueryPolymorphic when a row's discriminator value
+// has no registered variant.
+var ErrUnknownDiscriminator = errors.New("typedb: unknown discriminator value")
+
 // errNotMyType is returned by handler functions when they don't handle the target type.
 // This allows the main function to try the next handler without logging errors.
 var errNotMyType = errors.New("typedb: not my type")
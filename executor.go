@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"strings"
 	"time"
 )
 
@@ -46,6 +45,7 @@ func NewDBWithLoggerAndFlags(db *sql.DB, driverName string, timeout time.Duratio
 		logger:     logger,
 		logQueries: logQueries,
 		logArgs:    logArgs,
+		codecs:     newCodecRegistry(),
 	}
 }
 
@@ -126,7 +126,7 @@ func (d *DB) Exec(ctx context.Context, query string, args ...any) (sql.Result, e
 }
 
 // queryAllHelper executes a query and returns all rows as []map[string]any, with logging and timeout handling.
-func queryAllHelper(ctx context.Context, exec sqlQueryExecutor, logger Logger, timeout time.Duration, logQueries, logArgs bool, query string, args ...any) ([]map[string]any, error) {
+func queryAllHelper(ctx context.Context, exec sqlQueryExecutor, logger Logger, timeout time.Duration, logQueries, logArgs, preserveCase bool, query string, args ...any) ([]map[string]any, error) {
 	logger = getLoggerHelper(logger)
 	logQueries, logArgs, logArgsCopy := getLoggingFlagsAndArgs(ctx, logQueries, logArgs, args)
 
@@ -165,7 +165,7 @@ func queryAllHelper(ctx context.Context, exec sqlQueryExecutor, logger Logger, t
 		}
 	}()
 
-	result, err := scanRowsToMaps(rows)
+	result, err := scanRowsToMaps(rows, preserveCase, hasDuplicateColumnKeys(ctx))
 	if err != nil {
 		logger.Error("Failed to scan rows", "query", query, "error", err)
 		return nil, err
@@ -176,11 +176,11 @@ func queryAllHelper(ctx context.Context, exec sqlQueryExecutor, logger Logger, t
 // QueryAll implements Executor.QueryAll
 // Returns all rows as []map[string]any.
 func (d *DB) QueryAll(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
-	return queryAllHelper(ctx, d.db, d.logger, d.timeout, d.logQueries, d.logArgs, query, args...)
+	return queryAllHelper(ctx, d.db, d.logger, d.timeout, d.logQueries, d.logArgs, d.preserveColumnCase, query, args...)
 }
 
 // queryRowMapHelper executes a query and returns the first row as map[string]any, with logging and timeout handling.
-func queryRowMapHelper(ctx context.Context, exec sqlQueryExecutor, logger Logger, timeout time.Duration, logQueries, logArgs bool, query string, args ...any) (map[string]any, error) {
+func queryRowMapHelper(ctx context.Context, exec sqlQueryExecutor, logger Logger, timeout time.Duration, logQueries, logArgs, preserveCase bool, query string, args ...any) (map[string]any, error) {
 	logger = getLoggerHelper(logger)
 	logQueries, logArgs, logArgsCopy := getLoggingFlagsAndArgs(ctx, logQueries, logArgs, args)
 
@@ -232,7 +232,7 @@ func queryRowMapHelper(ctx context.Context, exec sqlQueryExecutor, logger Logger
 		return nil, ErrNotFound
 	}
 
-	row, err := scanRowToMap(rows)
+	row, err := scanRowToMap(rows, preserveCase, hasDuplicateColumnKeys(ctx))
 	if err != nil {
 		if logQueries {
 			logger.Error("Failed to scan row", "query", query, "error", err)
@@ -259,7 +259,7 @@ func queryRowMapHelper(ctx context.Context, exec sqlQueryExecutor, logger Logger
 // Returns the first row as map[string]any.
 // Returns ErrNotFound if no rows are returned.
 func (d *DB) QueryRowMap(ctx context.Context, query string, args ...any) (map[string]any, error) {
-	return queryRowMapHelper(ctx, d.db, d.logger, d.timeout, d.logQueries, d.logArgs, query, args...)
+	return queryRowMapHelper(ctx, d.db, d.logger, d.timeout, d.logQueries, d.logArgs, d.preserveColumnCase, query, args...)
 }
 
 // getIntoHelper scans a single row into dest pointers, with logging and timeout handling.
@@ -419,12 +419,16 @@ func (d *DB) Begin(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 	}
 
 	return &Tx{
-		tx:         tx,
-		driverName: d.driverName,
-		timeout:    d.timeout,
-		logger:     d.logger,
-		logQueries: d.logQueries,
-		logArgs:    d.logArgs,
+		tx:                 tx,
+		driverName:         d.driverName,
+		timeout:            d.timeout,
+		logger:             d.logger,
+		logQueries:         d.logQueries,
+		logArgs:            d.logArgs,
+		columnPolicy:       d.columnPolicy,
+		codecs:             d.codecs,
+		preserveColumnCase: d.preserveColumnCase,
+		times:              d.times,
 	}, nil
 }
 
@@ -457,12 +461,12 @@ func (t *Tx) Exec(ctx context.Context, query string, args ...any) (sql.Result, e
 
 // QueryAll implements Executor.QueryAll for transactions
 func (t *Tx) QueryAll(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
-	return queryAllHelper(ctx, t.tx, t.logger, t.timeout, t.logQueries, t.logArgs, query, args...)
+	return queryAllHelper(ctx, t.tx, t.logger, t.timeout, t.logQueries, t.logArgs, t.preserveColumnCase, query, args...)
 }
 
 // QueryRowMap implements Executor.QueryRowMap for transactions
 func (t *Tx) QueryRowMap(ctx context.Context, query string, args ...any) (map[string]any, error) {
-	return queryRowMapHelper(ctx, t.tx, t.logger, t.timeout, t.logQueries, t.logArgs, query, args...)
+	return queryRowMapHelper(ctx, t.tx, t.logger, t.timeout, t.logQueries, t.logArgs, t.preserveColumnCase, query, args...)
 }
 
 // GetInto implements Executor.GetInto for transactions
@@ -509,15 +513,18 @@ func (t *Tx) withTimeout(ctx context.Context) (context.Context, context.CancelFu
 }
 
 // scanRowsToMaps scans all rows into a slice of maps.
-func scanRowsToMaps(rows *sql.Rows) ([]map[string]any, error) {
+// Map keys are assigned by columnKeys.
+func scanRowsToMaps(rows *sql.Rows, preserveCase, keepDuplicates bool) ([]map[string]any, error) {
 	cols, err := rows.Columns()
 	if err != nil {
 		return nil, err
 	}
+	keys := columnKeys(cols, preserveCase, keepDuplicates)
+	binary := binaryColumns(rows)
 
 	var results []map[string]any
 	for rows.Next() {
-		row, err := scanRowToMapWithCols(rows, cols)
+		row, err := scanRowToMapWithCols(rows, keys, binary)
 		if err != nil {
 			return nil, err
 		}
@@ -531,10 +538,28 @@ func scanRowsToMaps(rows *sql.Rows) ([]map[string]any, error) {
 	return results, nil
 }
 
-// scanRowToMapWithCols scans a single row into a map[string]any using pre-fetched columns.
-func scanRowToMapWithCols(rows *sql.Rows, cols []string) (map[string]any, error) {
-	values := make([]any, len(cols))
-	valuePtrs := make([]any, len(cols))
+// scanRowToMapWithCols scans a single row into a map[string]any using pre-computed column keys.
+// []byte values are converted to string unless binary marks the column as a binary type
+// (BLOB, bytea, VARBINARY, ...), in which case they are kept as []byte.
+func scanRowToMapWithCols(rows *sql.Rows, keys []string, binary []bool) (map[string]any, error) {
+	values, err := scanRowValues(rows, len(keys), binary)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any)
+	for i, colKey := range keys {
+		result[colKey] = values[i]
+	}
+
+	return result, nil
+}
+
+// scanRowValues scans a single row into a slice of n column values, in result column order.
+// []byte values are converted as in scanRowToMapWithCols.
+func scanRowValues(rows *sql.Rows, n int, binary []bool) ([]any, error) {
+	values := make([]any, n)
+	valuePtrs := make([]any, n)
 	for i := range values {
 		valuePtrs[i] = &values[i]
 	}
@@ -543,28 +568,23 @@ func scanRowToMapWithCols(rows *sql.Rows, cols []string) (map[string]any, error)
 		return nil, err
 	}
 
-	result := make(map[string]any)
-	for i, col := range cols {
-		val := values[i]
-		colKey := strings.ToLower(col)
-		if b, ok := val.([]byte); ok {
-			result[colKey] = string(b)
-		} else {
-			result[colKey] = val
+	for i, val := range values {
+		if b, ok := val.([]byte); ok && (i >= len(binary) || !binary[i]) {
+			values[i] = string(b)
 		}
 	}
 
-	return result, nil
+	return values, nil
 }
 
 // scanRowToMap scans a single row into a map[string]any.
 // Assumes rows.Next() has already been called.
-func scanRowToMap(rows *sql.Rows) (map[string]any, error) {
+func scanRowToMap(rows *sql.Rows, preserveCase, keepDuplicates bool) (map[string]any, error) {
 	cols, err := rows.Columns()
 	if err != nil {
 		return nil, err
 	}
-	return scanRowToMapWithCols(rows, cols)
+	return scanRowToMapWithCols(rows, columnKeys(cols, preserveCase, keepDuplicates), binaryColumns(rows))
 }
 
 // openHelper contains the shared logic for opening database connections.
@@ -579,6 +599,7 @@ func openHelper(driverName, dsn string, validate bool, opts ...Option) (*DB, err
 		OpTimeout:       5 * time.Second,
 		LogQueries:      true,
 		LogArgs:         true,
+		codecs:          newCodecRegistry(),
 	}
 
 	for _, opt := range opts {
@@ -608,12 +629,40 @@ func openHelper(driverName, dsn string, validate bool, opts ...Option) (*DB, err
 	if validate {
 		logger.Info("Validating registered models")
 		MustValidateAllRegistered()
+		if err := validateRegisteredCollections(driverName, cfg.codecs); err != nil {
+			logger.Error("Model validation failed for driver", "driver", driverName, "error", err)
+			if closeErr := db.Close(); closeErr != nil {
+				logger.Error("Failed to close database connection", "error", closeErr)
+			}
+			return nil, err
+		}
 		logger.Info("Database connection opened successfully", "driver", driverName, "maxOpenConns", cfg.MaxOpenConns, "maxIdleConns", cfg.MaxIdleConns)
 	} else {
 		logger.Info("Database connection opened successfully (without validation)", "driver", driverName)
 	}
 
-	return NewDBWithLoggerAndFlags(db, driverName, cfg.OpTimeout, logger, cfg.LogQueries, cfg.LogArgs), nil
+	typedbDB := NewDBWithLoggerAndFlags(db, driverName, cfg.OpTimeout, logger, cfg.LogQueries, cfg.LogArgs)
+	typedbDB.columnPolicy = cfg.ColumnPolicy
+	typedbDB.preserveColumnCase = cfg.PreserveColumnCase
+	typedbDB.codecs = cfg.codecs
+	typedbDB.times = timeSettings{
+		location:  cfg.TimeLocation,
+		precision: cfg.TimePrecision,
+		normalize: cfg.NormalizeTimes,
+	}
+
+	if cfg.ValidateQueries {
+		logger.Info("Validating registered model queries against database")
+		if err := ValidateQueries(context.Background(), typedbDB); err != nil {
+			logger.Error("Model query validation failed", "driver", driverName, "error", err)
+			if closeErr := db.Close(); closeErr != nil {
+				logger.Error("Failed to close database connection", "error", closeErr)
+			}
+			return nil, err
+		}
+	}
+
+	return typedbDB, nil
 }
 
 // Open opens a database connection with validation.
@@ -690,6 +739,62 @@ func WithLogArgs(enabled bool) Option {
 	}
 }
 
+// WithQueryValidation sets whether Open runs ValidateQueries against the database
+// before returning. Each registered model's QueryBy* queries are prepared and checked
+// for placeholder count and column mapping; Open returns the validation error if any fail.
+// Default: false
+func WithQueryValidation(enabled bool) Option {
+	return func(cfg *Config) {
+		cfg.ValidateQueries = enabled
+	}
+}
+
+// WithColumnPolicy sets the default ColumnPolicy for queries run through the DB and its transactions.
+// A model's ModelOptions.ColumnPolicy and WithColumnPolicyOverride on the context take precedence.
+// Default: ColumnPolicyIgnore
+func WithColumnPolicy(policy ColumnPolicy) Option {
+	return func(cfg *Config) {
+		cfg.ColumnPolicy = policy
+	}
+}
+
+// WithTimeLocation sets the location assumed for timestamps read without zone information
+// (e.g., SQLite text columns) and parsed with timeFormat layouts or epoch values.
+// Default: UTC
+func WithTimeLocation(loc *time.Location) Option {
+	return func(cfg *Config) {
+		cfg.TimeLocation = loc
+	}
+}
+
+// WithTimeNormalization converts time.Time values to the WithTimeLocation location (UTC by default)
+// when writing with Insert and Update and when reading on every query path.
+// Default: false
+func WithTimeNormalization(enabled bool) Option {
+	return func(cfg *Config) {
+		cfg.NormalizeTimes = enabled
+	}
+}
+
+// WithTimePrecision truncates time.Time values to a multiple of d when writing with Insert and Update
+// (e.g., time.Microsecond to match PostgreSQL timestamp precision).
+// Default: 0 (no truncation)
+func WithTimePrecision(d time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.TimePrecision = d
+	}
+}
+
+// WithPreserveColumnCase keeps result column names as returned by the driver instead of lowercasing them.
+// Columns that differ only in case (e.g., "userId" and "userid") then stay distinct, and a column
+// that matches no db tag exactly is matched to a db tag case-insensitively.
+// Default: false (column names are lowercased)
+func WithPreserveColumnCase(enabled bool) Option {
+	return func(cfg *Config) {
+		cfg.PreserveColumnCase = enabled
+	}
+}
+
 // Context keys for logging overrides
 type logOverrideKey struct{}
 type maskIndicesKey struct{}
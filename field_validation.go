@@ -0,0 +1,398 @@
+package typedb
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FieldValidationError is a validate tag rule that failed for a model field.
+type FieldValidationError struct {
+	Field   string // Go field name
+	Column  string // Column name from the db tag
+	Rule    string // Rule name (e.g., "max")
+	Param   string // Rule parameter (e.g., "255"); empty for rules without one
+	Message string // Why the value failed the rule
+}
+
+// Error implements the error interface.
+func (e FieldValidationError) Error() string {
+	rule := e.Rule
+	if e.Param != "" {
+		rule += "=" + e.Param
+	}
+	return fmt.Sprintf("field %s (%s) failed %q: %s", e.Field, e.Column, rule, e.Message)
+}
+
+// FieldValidationErrors lists every validate tag rule a model failed.
+// It is returned by Insert, InsertAndLoad and Update before any SQL is built.
+type FieldValidationErrors struct {
+	ModelName string
+	Errors    []FieldValidationError
+}
+
+// Error implements the error interface.
+func (e *FieldValidationErrors) Error() string {
+	parts := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		parts[i] = err.Error()
+	}
+	return fmt.Sprintf("typedb: field validation failed for model %s:\n  %s", e.ModelName, strings.Join(parts, "\n  "))
+}
+
+// validateRule is a parsed rule of a validate tag.
+type validateRule struct {
+	name  string
+	param string
+}
+
+// builtinRule checks a non-zero field value for a built-in validate rule.
+type builtinRule struct {
+	check      func(v reflect.Value, param string) string // Returns a failure message, or "" if v passes
+	checkParam func(t reflect.Type, param string) error   // Checks the rule applies to the field type
+}
+
+const validateRuleRequired = "required"
+
+var builtinRules = map[string]builtinRule{
+	"min":   {check: checkMin, checkParam: checkSizeParam},
+	"max":   {check: checkMax, checkParam: checkSizeParam},
+	"email": {check: checkEmail, checkParam: checkEmailParam},
+	"oneof": {check: checkOneOf, checkParam: checkOneOfParam},
+}
+
+// customRules holds rules registered with RegisterValidationRule.
+var customRules sync.Map // map[string]func(value any, param string) error
+
+// nullSourceType is the interface implemented by every Null[T].
+var nullSourceType = reflect.TypeOf((*nullSource)(nil)).Elem()
+
+// RegisterValidationRule registers a custom rule usable in validate tags as name or name=param.
+// The rule receives the field value (dereferenced, and unwrapped for Null[T]) and the tag parameter,
+// and returns an error describing why the value is invalid. Like built-in rules other than required,
+// it is only called for non-empty values.
+// Register rules before the models using them (e.g., in an earlier init()), since RegisterModel
+// rejects unknown rule names. Registering an existing custom rule replaces it.
+// Panics if name is empty, contains ",", "=" or spaces, or is a built-in rule.
+//
+// Example:
+//
+//	typedb.RegisterValidationRule("prefix", func(value any, param string) error {
+//	    if !strings.HasPrefix(fmt.Sprint(value), param) {
+//	        return fmt.Errorf("must start with %q", param)
+//	    }
+//	    return nil
+//	})
+//
+//	type Order struct {
+//	    Code string `db:"code" validate:"required,prefix=ORD-"`
+//	}
+func RegisterValidationRule(name string, rule func(value any, param string) error) {
+	if name == "" || strings.ContainsAny(name, ",= \t") {
+		panic(fmt.Errorf("typedb: invalid validation rule name %q", name))
+	}
+	if _, builtin := builtinRules[name]; builtin || name == validateRuleRequired {
+		panic(fmt.Errorf("typedb: validation rule %q is built in", name))
+	}
+	customRules.Store(name, rule)
+}
+
+// parseValidateTag splits a validate tag into rules.
+func parseValidateTag(tag string) ([]validateRule, error) {
+	var rules []validateRule
+	for _, part := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name == "" {
+			return nil, fmt.Errorf("invalid validate tag %q: empty rule", tag)
+		}
+		rules = append(rules, validateRule{name: name, param: param})
+	}
+	return rules, nil
+}
+
+// validateValueType returns the type validate rules apply to: pointers are dereferenced
+// and Null[T] is unwrapped to T.
+func validateValueType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct && t.Implements(nullSourceType) {
+		t = t.Field(0).Type
+	}
+	return t
+}
+
+// checkRuleDefinition reports whether rule is known and usable on fieldType.
+func checkRuleDefinition(rule validateRule, fieldType reflect.Type) error {
+	if rule.name == validateRuleRequired {
+		if rule.param != "" {
+			return fmt.Errorf("rule %q takes no parameter", rule.name)
+		}
+		return nil
+	}
+	if builtin, ok := builtinRules[rule.name]; ok {
+		if err := builtin.checkParam(validateValueType(fieldType), rule.param); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.name, err)
+		}
+		return nil
+	}
+	if _, ok := customRules.Load(rule.name); ok {
+		return nil
+	}
+	return fmt.Errorf("unknown validation rule %q", rule.name)
+}
+
+// validateValidateTags checks the syntax of validate tags and that their rules apply to the field types.
+func validateValidateTags(t reflect.Type) []string {
+	var errors []string
+
+	var check func(reflect.Type)
+	check = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					check(embeddedType)
+					continue
+				}
+			}
+			tag, ok := field.Tag.Lookup("validate")
+			if !ok {
+				continue
+			}
+
+			dbTag := field.Tag.Get("db")
+			if dbTag == "" || dbTag == "-" || dbTag == collectColumnsTag || isNestedStructField(field, nil) {
+				errors = append(errors, fmt.Sprintf("field %s: validate tag requires a db column", field.Name))
+				continue
+			}
+			rules, err := parseValidateTag(tag)
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("field %s: %v", field.Name, err))
+				continue
+			}
+			for _, rule := range rules {
+				if err := checkRuleDefinition(rule, field.Type); err != nil {
+					errors = append(errors, fmt.Sprintf("field %s: %v", field.Name, err))
+				}
+			}
+		}
+	}
+
+	check(t)
+	return errors
+}
+
+// validateModelFields checks the validate tags of the columns Insert or Update would write,
+// skipping the primary key field. Empty values (nil pointers, NULL or unset Null[T], and zero values
+// of other types) only fail required, and other rules apply to non-empty values. Update does not write
+// zero values, so it only enforces required on explicit NULLs.
+// dbCodecs holds the DB-level codecs of the executor.
+// Returns *FieldValidationErrors listing every failure.
+func validateModelFields(model ModelInterface, primaryKeyFieldName string, forUpdate bool, dbCodecs *codecRegistry) error {
+	modelValue := reflect.ValueOf(model)
+	if modelValue.Kind() != reflect.Ptr || modelValue.IsNil() {
+		return nil
+	}
+	structValue := modelValue.Elem()
+
+	var failures []FieldValidationError
+	var tagErr error
+	iterateStructFields(structValue.Type(), structValue, primaryKeyFieldName, dbCodecs, func(field reflect.StructField, fieldValue reflect.Value, columnName string) bool {
+		tag, ok := field.Tag.Lookup("validate")
+		if !ok {
+			return true
+		}
+		rules, err := parseValidateTag(tag)
+		if err != nil {
+			tagErr = fmt.Errorf("typedb: field %s: %w", field.Name, err)
+			return false
+		}
+
+		value, empty := validateFieldValue(fieldValue)
+		enforceRequired := !forUpdate || !isZeroOrNil(fieldValue)
+		for _, rule := range rules {
+			message, err := applyValidateRule(rule, value, empty, enforceRequired)
+			if err != nil {
+				tagErr = fmt.Errorf("typedb: field %s: %w", field.Name, err)
+				return false
+			}
+			if message != "" {
+				failures = append(failures, FieldValidationError{
+					Field:   field.Name,
+					Column:  columnName,
+					Rule:    rule.name,
+					Param:   rule.param,
+					Message: message,
+				})
+			}
+		}
+		return true
+	})
+	if tagErr != nil {
+		return tagErr
+	}
+
+	if len(failures) > 0 {
+		return &FieldValidationErrors{ModelName: structValue.Type().Name(), Errors: failures}
+	}
+	return nil
+}
+
+// validateFieldValue dereferences pointers and unwraps Null[T]. empty is true for nil pointers,
+// NULL or unset Null values, and zero values of other types.
+func validateFieldValue(v reflect.Value) (value reflect.Value, empty bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, true
+		}
+		v = v.Elem()
+	}
+	if inner, valid, _, ok := asNullSource(v); ok {
+		return inner, !valid
+	}
+	return v, isZeroOrNil(v)
+}
+
+// applyValidateRule checks a single rule, returning a failure message or "" if the value passes.
+func applyValidateRule(rule validateRule, value reflect.Value, empty, enforceRequired bool) (string, error) {
+	if rule.name == validateRuleRequired {
+		if empty && enforceRequired {
+			return "value is required", nil
+		}
+		return "", nil
+	}
+	if empty {
+		return "", nil
+	}
+
+	if builtin, ok := builtinRules[rule.name]; ok {
+		return builtin.check(value, rule.param), nil
+	}
+	custom, ok := customRules.Load(rule.name)
+	if !ok {
+		return "", fmt.Errorf("unknown validation rule %q", rule.name)
+	}
+	if err := custom.(func(any, string) error)(value.Interface(), rule.param); err != nil {
+		return err.Error(), nil
+	}
+	return "", nil
+}
+
+// checkSizeParam checks min/max have a numeric parameter and apply to a sized or numeric type.
+func checkSizeParam(t reflect.Type, param string) error {
+	if _, err := strconv.ParseFloat(param, 64); err != nil {
+		return fmt.Errorf("parameter %q is not a number", param)
+	}
+	if _, ok := sizeKind(t.Kind()); !ok {
+		return fmt.Errorf("cannot be used on %v fields", t)
+	}
+	return nil
+}
+
+// sizeKind reports whether min/max compare the length (true) or the value (false) of a kind.
+// ok is false for kinds min/max do not support.
+func sizeKind(kind reflect.Kind) (length, ok bool) {
+	switch kind {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return true, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// validateSize returns the length or numeric value of v compared by min/max.
+func validateSize(v reflect.Value) (size float64, length bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(v.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), false
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), false
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false
+	}
+	return 0, false
+}
+
+func checkMin(v reflect.Value, param string) string {
+	limit, _ := strconv.ParseFloat(param, 64)
+	size, length := validateSize(v)
+	if size >= limit {
+		return ""
+	}
+	if length {
+		return fmt.Sprintf("length %v is less than %s", size, param)
+	}
+	return fmt.Sprintf("value %v is less than %s", v.Interface(), param)
+}
+
+func checkMax(v reflect.Value, param string) string {
+	limit, _ := strconv.ParseFloat(param, 64)
+	size, length := validateSize(v)
+	if size <= limit {
+		return ""
+	}
+	if length {
+		return fmt.Sprintf("length %v exceeds %s", size, param)
+	}
+	return fmt.Sprintf("value %v exceeds %s", v.Interface(), param)
+}
+
+// checkEmailParam checks email has no parameter and applies to a string type.
+func checkEmailParam(t reflect.Type, param string) error {
+	if param != "" {
+		return fmt.Errorf("takes no parameter")
+	}
+	if t.Kind() != reflect.String {
+		return fmt.Errorf("cannot be used on %v fields", t)
+	}
+	return nil
+}
+
+func checkEmail(v reflect.Value, _ string) string {
+	address, err := mail.ParseAddress(v.String())
+	if err != nil || address.Address != v.String() {
+		return fmt.Sprintf("%q is not an email address", v.String())
+	}
+	return ""
+}
+
+// checkOneOfParam checks oneof lists values and applies to a string or numeric type.
+func checkOneOfParam(t reflect.Type, param string) error {
+	if param == "" {
+		return fmt.Errorf("requires values separated by |")
+	}
+	if length, ok := sizeKind(t.Kind()); !ok || (length && t.Kind() != reflect.String) {
+		return fmt.Errorf("cannot be used on %v fields", t)
+	}
+	return nil
+}
+
+func checkOneOf(v reflect.Value, param string) string {
+	value := fmt.Sprint(v.Interface())
+	for _, allowed := range strings.Split(param, "|") {
+		if value == allowed {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%q is not one of %s", value, strings.ReplaceAll(param, "|", ", "))
+}
@@ -0,0 +1,190 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func init() {
+	RegisterValidationRule("fieldtest_prefix", func(value any, param string) error {
+		if !strings.HasPrefix(fmt.Sprint(value), param) {
+			return fmt.Errorf("must start with %q", param)
+		}
+		return nil
+	})
+}
+
+// FieldValidationTestUser is a test model with validate tags
+type FieldValidationTestUser struct {
+	Model
+	Nickname *string      `db:"nickname" validate:"min=3"`
+	Code     Null[string] `db:"code" validate:"required,fieldtest_prefix=U-"`
+	Email    string       `db:"email" validate:"required,email,max=20"`
+	Role     string       `db:"role" validate:"oneof=admin|editor"`
+	Age      int          `db:"age" validate:"max=150"`
+	ID       int64        `db:"id" load:"primary" validate:"required"`
+}
+
+func (u *FieldValidationTestUser) TableName() string {
+	return "users"
+}
+
+func (u *FieldValidationTestUser) QueryByID() string {
+	return "SELECT id, email, role, age, nickname, code FROM users WHERE id = ?"
+}
+
+// FieldValidationTestInvalid is a test model with invalid validate tags
+type FieldValidationTestInvalid struct {
+	Model
+	Tags    []string `db:"tags" validate:"email"`
+	Name    string   `db:"name" validate:"required,,max=3"`
+	Bio     string   `db:"bio" validate:"max=ten"`
+	Kind    string   `db:"kind" validate:"oneof"`
+	Status  string   `db:"status" validate:"unknown_rule"`
+	Display string   `db:"-" validate:"required"`
+	ID      int64    `db:"id" load:"primary"`
+}
+
+func (m *FieldValidationTestInvalid) QueryByID() string {
+	return "SELECT id FROM invalid WHERE id = ?"
+}
+
+func failedRules(err error) []string {
+	var fieldErrs *FieldValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return nil
+	}
+	var rules []string
+	for _, e := range fieldErrs.Errors {
+		rules = append(rules, e.Field+":"+e.Rule)
+	}
+	return rules
+}
+
+func TestValidateModelFields(t *testing.T) {
+	short := "ab"
+	tests := []struct {
+		name      string
+		user      *FieldValidationTestUser
+		forUpdate bool
+		want      string
+	}{
+		{
+			name: "valid",
+			user: &FieldValidationTestUser{Email: "a@example.com", Role: "admin", Code: NewNull("U-1")},
+		},
+		{
+			name: "every failing rule",
+			user: &FieldValidationTestUser{Email: "this is not an email address", Role: "owner", Age: 200, Nickname: &short, Code: NewNull("X")},
+			want: "Nickname:min,Code:fieldtest_prefix,Email:email,Email:max,Role:oneof,Age:max",
+		},
+		{
+			name: "required on insert",
+			user: &FieldValidationTestUser{},
+			want: "Code:required,Email:required",
+		},
+		{
+			name:      "update skips zero values",
+			user:      &FieldValidationTestUser{Age: 3},
+			forUpdate: true,
+		},
+		{
+			name:      "update rejects explicit NULL",
+			user:      &FieldValidationTestUser{Code: NullOf[string]()},
+			forUpdate: true,
+			want:      "Code:required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateModelFields(tt.user, "ID", tt.forUpdate, nil)
+			if got := strings.Join(failedRules(err), ","); got != tt.want || (tt.want == "" && err != nil) {
+				t.Errorf("Expected failures %q, got %q (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestFieldValidationErrors_Message(t *testing.T) {
+	err := validateModelFields(&FieldValidationTestUser{Email: "a@example.com", Code: NewNull("U-1"), Age: 151}, "ID", false, nil)
+	want := `typedb: field validation failed for model FieldValidationTestUser:
+  field Age (age) failed "max=150": value 151 exceeds 150`
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected %q, got %v", want, err)
+	}
+}
+
+func TestValidateModel_ValidateTags(t *testing.T) {
+	if err := ValidateModel(&FieldValidationTestUser{}); err != nil {
+		t.Errorf("Expected valid tags, got %v", err)
+	}
+
+	err := ValidateModel(&FieldValidationTestInvalid{})
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+	for _, want := range []string{
+		`field Tags: rule "email": cannot be used on []string fields`,
+		`field Name: invalid validate tag "required,,max=3": empty rule`,
+		`field Bio: rule "max": parameter "ten" is not a number`,
+		`field Kind: rule "oneof": requires values separated by |`,
+		`field Status: unknown validation rule "unknown_rule"`,
+		"field Display: validate tag requires a db column",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestRegisterValidationRule_Panics(t *testing.T) {
+	for _, name := range []string{"", "a,b", "required", "max"} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			RegisterValidationRule(name, func(any, string) error { return nil })
+		})
+	}
+}
+
+func TestFieldValidation_InsertAndUpdate(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT, role TEXT, age INTEGER, nickname TEXT, code TEXT)"); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+
+	_, err = InsertAndLoad(ctx, db, &FieldValidationTestUser{Email: "bad", Code: NewNull("U-1")})
+	var fieldErrs *FieldValidationErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs.Errors) != 1 || fieldErrs.Errors[0].Column != "email" {
+		t.Errorf("Expected FieldValidationErrors for email, got %v", err)
+	}
+
+	user := &FieldValidationTestUser{Email: "a@example.com", Code: NewNull("U-1")}
+	if err := Insert(ctx, db, user); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	if err := Update(ctx, db, &FieldValidationTestUser{ID: user.ID, Role: "guest"}); !errors.As(err, &fieldErrs) {
+		t.Errorf("Expected FieldValidationErrors from Update, got %v", err)
+	}
+	if err := Update(ctx, db, &FieldValidationTestUser{ID: user.ID, Role: "editor"}); err != nil {
+		t.Errorf("Expected sparse update to pass, got %v", err)
+	}
+
+	row, err := db.QueryRowMap(ctx, "SELECT COUNT(*) AS n FROM users WHERE role = 'editor'")
+	if err != nil || row["n"] != int64(1) {
+		t.Errorf("Expected only the valid update to be written, got %v, %v", row, err)
+	}
+}
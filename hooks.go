@@ -0,0 +1,127 @@
+package typedb
+
+import (
+	"context"
+	"fmt"
+)
+
+// BeforeInsertHook is implemented by models that run logic before Insert, InsertAndLoad, InsertMany
+// and Upsert. It runs before the model is serialized, so changes it makes to fields are inserted.
+// Returning an error aborts the insert. exec is the executor passed to the insert, so queries the
+// hook runs take part in the caller's transaction.
+type BeforeInsertHook interface {
+	BeforeInsert(ctx context.Context, exec Executor) error
+}
+
+// AfterInsertHook is implemented by models that run logic after a successful insert.
+// The primary key field is already set. exec is the executor of the insert. An error is returned
+// by Insert, but the row stays inserted unless exec is a transaction the caller rolls back.
+type AfterInsertHook interface {
+	AfterInsert(ctx context.Context, exec Executor) error
+}
+
+// BeforeUpdateHook is implemented by models that run logic before Update and Upsert.
+// It runs before changed fields are detected and serialized, so changes it makes to fields are written.
+// Returning an error aborts the update. Queries run on exec share the update's transaction.
+type BeforeUpdateHook interface {
+	BeforeUpdate(ctx context.Context, exec Executor) error
+}
+
+// AfterUpdateHook is implemented by models that run logic after a successful Update or Upsert,
+// with the executor of the update. An error is returned by Update, but the row stays updated
+// unless exec is a transaction the caller rolls back.
+type AfterUpdateHook interface {
+	AfterUpdate(ctx context.Context, exec Executor) error
+}
+
+// BeforeDeleteHook is implemented by models that run logic before Delete, DeleteByField,
+// DeleteByComposite and DeleteMany, with the executor of the delete. Returning an error aborts the delete.
+type BeforeDeleteHook interface {
+	BeforeDelete(ctx context.Context, exec Executor) error
+}
+
+// AfterDeleteHook is implemented by models that run logic after a successful delete, with the
+// executor of the delete. An error is returned by the delete function, but the row stays deleted
+// unless exec is a transaction the caller rolls back.
+type AfterDeleteHook interface {
+	AfterDelete(ctx context.Context, exec Executor) error
+}
+
+// AfterLoadHook is implemented by models that run logic after being deserialized from a query result,
+// e.g. to compute derived fields. It runs for every model returned by the query functions and Load*,
+// after the partial-update original copy is saved, and for the children QueryAggregate appends to
+// slice fields, before their parent. Nested struct fields filled from prefixed columns do not run it.
+// exec is the executor of the query. Returning an error fails the query.
+type AfterLoadHook interface {
+	AfterLoad(ctx context.Context, exec Executor) error
+}
+
+// runBeforeInsert calls model.BeforeInsert if the model implements BeforeInsertHook.
+func runBeforeInsert(ctx context.Context, exec Executor, model any) error {
+	if hook, ok := model.(BeforeInsertHook); ok {
+		if err := hook.BeforeInsert(ctx, exec); err != nil {
+			return fmt.Errorf("typedb: BeforeInsert hook failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// runAfterInsert calls model.AfterInsert if the model implements AfterInsertHook.
+func runAfterInsert(ctx context.Context, exec Executor, model any) error {
+	if hook, ok := model.(AfterInsertHook); ok {
+		if err := hook.AfterInsert(ctx, exec); err != nil {
+			return fmt.Errorf("typedb: AfterInsert hook failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// runBeforeUpdate calls model.BeforeUpdate if the model implements BeforeUpdateHook.
+func runBeforeUpdate(ctx context.Context, exec Executor, model any) error {
+	if hook, ok := model.(BeforeUpdateHook); ok {
+		if err := hook.BeforeUpdate(ctx, exec); err != nil {
+			return fmt.Errorf("typedb: BeforeUpdate hook failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// runAfterUpdate calls model.AfterUpdate if the model implements AfterUpdateHook.
+func runAfterUpdate(ctx context.Context, exec Executor, model any) error {
+	if hook, ok := model.(AfterUpdateHook); ok {
+		if err := hook.AfterUpdate(ctx, exec); err != nil {
+			return fmt.Errorf("typedb: AfterUpdate hook failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// runBeforeDelete calls model.BeforeDelete if the model implements BeforeDeleteHook.
+func runBeforeDelete(ctx context.Context, exec Executor, model any) error {
+	if hook, ok := model.(BeforeDeleteHook); ok {
+		if err := hook.BeforeDelete(ctx, exec); err != nil {
+			return fmt.Errorf("typedb: BeforeDelete hook failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// runAfterDelete calls model.AfterDelete if the model implements AfterDeleteHook.
+func runAfterDelete(ctx context.Context, exec Executor, model any) error {
+	if hook, ok := model.(AfterDeleteHook); ok {
+		if err := hook.AfterDelete(ctx, exec); err != nil {
+			return fmt.Errorf("typedb: AfterDelete hook failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// runAfterLoad calls model.AfterLoad if the model implements AfterLoadHook.
+func runAfterLoad(ctx context.Context, exec Executor, model any) error {
+	if hook, ok := model.(AfterLoadHook); ok {
+		if err := hook.AfterLoad(ctx, exec); err != nil {
+			return fmt.Errorf("typedb: AfterLoad hook failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// HookTestUser is a test model implementing every lifecycle hook
+type HookTestUser struct {
+	Model
+	Calls   []string `db:"-"`
+	Email   string   `db:"email"`
+	Display string   `db:"-"`
+	ID      int64    `db:"id" load:"primary"`
+}
+
+func (u *HookTestUser) TableName() string {
+	return "users"
+}
+
+func (u *HookTestUser) QueryByID() string {
+	return "SELECT id, email FROM users WHERE id = ?"
+}
+
+var errHookTestEmail = errors.New("email is required")
+
+func (u *HookTestUser) BeforeInsert(ctx context.Context, exec Executor) error {
+	u.Email = strings.ToLower(strings.TrimSpace(u.Email))
+	if u.Email == "" {
+		return errHookTestEmail
+	}
+	u.Calls = append(u.Calls, "before-insert")
+	_, err := exec.Exec(ctx, "INSERT INTO audit (action) VALUES ('insert')")
+	return err
+}
+
+func (u *HookTestUser) AfterInsert(ctx context.Context, exec Executor) error {
+	u.Calls = append(u.Calls, fmt.Sprintf("after-insert:%d", u.ID))
+	return nil
+}
+
+func (u *HookTestUser) BeforeUpdate(ctx context.Context, exec Executor) error {
+	u.Email = strings.ToLower(strings.TrimSpace(u.Email))
+	if u.Email == "" {
+		return errHookTestEmail
+	}
+	u.Calls = append(u.Calls, "before-update")
+	return nil
+}
+
+func (u *HookTestUser) AfterUpdate(ctx context.Context, exec Executor) error {
+	u.Calls = append(u.Calls, "after-update")
+	return nil
+}
+
+func (u *HookTestUser) AfterLoad(ctx context.Context, exec Executor) error {
+	u.Display = "<" + u.Email + ">"
+	return nil
+}
+
+func openHookTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT)",
+		"CREATE TABLE audit (action TEXT)",
+	} {
+		if _, err := db.Exec(context.Background(), stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+	return db
+}
+
+func countHookTestRows(t *testing.T, db *DB, table string) int64 {
+	t.Helper()
+	row, err := db.QueryRowMap(context.Background(), "SELECT COUNT(*) AS n FROM "+table)
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	return row["n"].(int64)
+}
+
+func TestHooks_InsertAndUpdate(t *testing.T) {
+	db := openHookTestDB(t)
+	defer closeDB(t, db)
+	ctx := context.Background()
+
+	user := &HookTestUser{Email: "  Alice@Example.COM "}
+	if err := Insert(ctx, db, user); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if user.Email != "alice@example.com" || strings.Join(user.Calls, ",") != "before-insert,after-insert:1" {
+		t.Errorf("Unexpected hook effects: email %q, calls %v", user.Email, user.Calls)
+	}
+
+	loaded := &HookTestUser{ID: user.ID}
+	if err := Load(ctx, db, loaded); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Email != "alice@example.com" || loaded.Display != "<alice@example.com>" {
+		t.Errorf("Expected the normalized email and AfterLoad display, got %+v", loaded)
+	}
+
+	loaded.Email = " BOB@example.com"
+	if err := Update(ctx, db, loaded); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if strings.Join(loaded.Calls, ",") != "before-update,after-update" {
+		t.Errorf("Unexpected update hooks %v", loaded.Calls)
+	}
+
+	users, err := QueryAll[*HookTestUser](ctx, db, "SELECT id, email FROM users")
+	if err != nil {
+		t.Fatalf("QueryAll failed: %v", err)
+	}
+	if len(users) != 1 || users[0].Display != "<bob@example.com>" {
+		t.Errorf("Expected AfterLoad on query results, got %+v", users)
+	}
+
+	loaded.Email = " "
+	if err := Update(ctx, db, loaded); !errors.Is(err, errHookTestEmail) {
+		t.Errorf("Expected BeforeUpdate error, got %v", err)
+	}
+	if err := Insert(ctx, db, &HookTestUser{}); !errors.Is(err, errHookTestEmail) {
+		t.Errorf("Expected BeforeInsert error, got %v", err)
+	}
+	if n := countHookTestRows(t, db, "users"); n != 1 {
+		t.Errorf("Expected aborted operations to write nothing, got %d users", n)
+	}
+}
+
+func TestHooks_RunInTransaction(t *testing.T) {
+	db := openHookTestDB(t)
+	defer closeDB(t, db)
+	ctx := context.Background()
+
+	tx, err := db.Begin(ctx, nil)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if err := Insert(ctx, tx, &HookTestUser{Email: "carol@example.com"}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+
+	if n := countHookTestRows(t, db, "audit"); n != 0 {
+		t.Errorf("Expected the hook's write to be rolled back with the transaction, got %d audit rows", n)
+	}
+}
+
+func TestHooks_AfterLoadError(t *testing.T) {
+	mock := aggregateTestRows(map[string]any{"id": int64(1), "name": "Alice"})
+	failing := errors.New("boom")
+	ctx := context.Background()
+
+	opts := newDeserializeOptions(ctx, mock)
+	opts.afterLoad = func(ModelInterface) error { return failing }
+	if _, err := deserializeForTypeWithOptions[*HookTestUser](map[string]any{"id": int64(1)}, opts); !errors.Is(err, failing) {
+		t.Errorf("Expected AfterLoad error to fail deserialization, got %v", err)
+	}
+
+	if _, err := deserializeForType[*HookTestUser](map[string]any{"id": int64(1), "email": "a"}); err != nil {
+		t.Errorf("Expected internal deserialization without an executor to skip hooks, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package typedb
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -342,7 +343,8 @@ func buildReturningClause(driverName, primaryKeyColumn string) string {
 type fieldVisitor func(field reflect.StructField, fieldValue reflect.Value, columnName string) bool
 
 // iterateStructFields visits struct fields (embedded, db tag); calls visitor for each valid field.
-func iterateStructFields(structType reflect.Type, structValue reflect.Value, primaryKeyFieldName string, visitor fieldVisitor) {
+// Nested struct fields (see isNestedStructField) are not visited; dbCodecs holds the DB-level codecs.
+func iterateStructFields(structType reflect.Type, structValue reflect.Value, primaryKeyFieldName string, dbCodecs *codecRegistry, visitor fieldVisitor) {
 	if structType.Kind() != reflect.Struct {
 		return
 	}
@@ -377,7 +379,12 @@ func iterateStructFields(structType reflect.Type, structValue reflect.Value, pri
 			}
 
 			dbTag := field.Tag.Get("db")
-			if dbTag == "" || dbTag == "-" {
+			if dbTag == "" || dbTag == "-" || dbTag == collectColumnsTag {
+				continue
+			}
+
+			// Nested structs are hydrated from joined columns and are not columns of the model's table
+			if isNestedStructField(field, dbCodecs) {
 				continue
 			}
 
@@ -402,6 +409,11 @@ func iterateStructFields(structType reflect.Type, structValue reflect.Value, pri
 
 // serializeModelFields collects non-nil/non-zero fields from a model for INSERT.
 func serializeModelFields(model ModelInterface, primaryKeyFieldName string) (columns []string, values []any, maskIndices []int, err error) {
+	return serializeModelFieldsWithOptions(model, primaryKeyFieldName, serializeOptions{})
+}
+
+// serializeModelFieldsWithOptions is serializeModelFields with DB-level settings applied.
+func serializeModelFieldsWithOptions(model ModelInterface, primaryKeyFieldName string, opts serializeOptions) (columns []string, values []any, maskIndices []int, err error) {
 	modelValue := reflect.ValueOf(model)
 	if modelValue.Kind() != reflect.Ptr || modelValue.IsNil() {
 		return nil, nil, nil, fmt.Errorf("typedb: model must be a non-nil pointer")
@@ -416,7 +428,7 @@ func serializeModelFields(model ModelInterface, primaryKeyFieldName string) (col
 	values = []any{}
 	maskIndices = []int{}
 
-	iterateStructFields(modelValue.Type(), modelValue, primaryKeyFieldName, func(field reflect.StructField, fieldValue reflect.Value, columnName string) bool {
+	iterateStructFields(modelValue.Type(), modelValue, primaryKeyFieldName, opts.codecs, func(field reflect.StructField, fieldValue reflect.Value, columnName string) bool {
 		if field.Tag.Get("dbInsert") == "false" {
 			return true
 		}
@@ -425,21 +437,107 @@ func serializeModelFields(model ModelInterface, primaryKeyFieldName string) (col
 			return true
 		}
 
+		value, valueErr := serializeColumnValue(field, fieldValue, opts)
+		if valueErr != nil {
+			err = fmt.Errorf("typedb: field %s: %w", field.Name, valueErr)
+			return false
+		}
+
 		shouldMask := field.Tag.Get("nolog") == "true"
 		if shouldMask {
 			maskIndices = append(maskIndices, len(values))
 		}
 
 		columns = append(columns, columnName)
-		values = append(values, fieldValue.Interface())
+		values = append(values, value)
 		return true
 	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	return columns, values, maskIndices, nil
 }
 
+// asValuer returns the driver.Valuer implemented by v or, for addressable values, by its pointer.
+// Nil pointers and interfaces are never Valuers, since calling a value-receiver Value on them panics.
+func asValuer(v reflect.Value) (driver.Valuer, bool) {
+	if isNilOnly(v) || !v.CanInterface() {
+		return nil, false
+	}
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		return valuer, true
+	}
+	if v.CanAddr() && reflect.PointerTo(v.Type()).Implements(valuerType) {
+		valuer, ok := v.Addr().Interface().(driver.Valuer)
+		return valuer, ok
+	}
+	return nil, false
+}
+
+// serializeOptions carries DB-level settings that affect how field values are written.
+// The zero value applies global settings only.
+type serializeOptions struct {
+	codecs     *codecRegistry // From WithCodec / RegisterDBCodec
+	times      timeSettings   // From WithTimeLocation / WithTimeNormalization / WithTimePrecision
+	driverName string
+}
+
+// newSerializeOptions resolves serialization settings from the executor.
+func newSerializeOptions(exec Executor) serializeOptions {
+	return serializeOptions{
+		codecs:     getExecutorCodecs(exec),
+		times:      getExecutorTimeSettings(exec),
+		driverName: getDriverName(exec),
+	}
+}
+
+// serializeFieldValue returns the value to bind for a field. In order of precedence:
+// registered codecs, driver.Valuer, built-in codecs, byte arrays as []byte, the dialect-aware
+// slice/map encoder, then the value as-is.
+func serializeFieldValue(v reflect.Value, opts serializeOptions) (any, error) {
+	if value, ok, err := encodeWithCodec(v, func(t reflect.Type) (codec, bool) { return lookupCodec(opts.codecs, t) }); ok {
+		return value, err
+	}
+	if valuer, ok := asValuer(v); ok {
+		return valuer.Value()
+	}
+	if value, ok, err := encodeWithCodec(v, builtinCodec); ok {
+		return value, err
+	}
+	if value, ok := encodeByteArray(v); ok {
+		return value, nil
+	}
+	if v.Kind() == reflect.Ptr && !v.IsNil() && isCollectionType(v.Type().Elem()) {
+		v = v.Elem()
+	}
+	if isCollectionType(v.Type()) && !v.IsNil() {
+		return encodeCollection(v, opts.driverName)
+	}
+	return v.Interface(), nil
+}
+
 // isZeroOrNil checks if a reflect.Value is zero or nil.
+// driver.Valuer fields (e.g., sql.NullString) are NULL when Value() returns nil.
 func isZeroOrNil(v reflect.Value) bool {
+	// Null[T] is omitted only when unset; explicit NULL and valid zero values are written
+	if _, _, unset, ok := asNullSource(v); ok {
+		return unset
+	}
+
+	// Decimals are zero when numerically zero, so Decimal{} and a parsed "0.00" behave alike
+	if v.Type() == decimalType {
+		d, _ := v.Interface().(Decimal)
+		return d.IsZero()
+	}
+
+	if valuer, ok := asValuer(v); ok {
+		// Errors are surfaced when the field is serialized
+		if value, err := valuer.Value(); err == nil && value == nil {
+			return true
+		}
+	}
+
 	switch v.Kind() {
 	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
 		return v.IsNil()
@@ -478,6 +576,7 @@ func isNilOnly(v reflect.Value) bool {
 //
 // Nil/zero value fields are excluded from the INSERT.
 // The primary key field is set on the model after insertion.
+// Models implementing BeforeInsertHook or AfterInsertHook have them called before and after the insert.
 //
 // Example:
 //
@@ -602,6 +701,14 @@ func insertWithReturning[T ModelInterface](ctx context.Context, exec Executor, m
 }
 
 func Insert[T ModelInterface](ctx context.Context, exec Executor, model T) error {
+	if err := insertModel(ctx, exec, model); err != nil {
+		return err
+	}
+	return runAfterInsert(ctx, exec, model)
+}
+
+// insertModel runs the BeforeInsert hook and inserts the model, setting its primary key.
+func insertModel[T ModelInterface](ctx context.Context, exec Executor, model T) error {
 	tableName, err := getTableName(model)
 	if err != nil {
 		return fmt.Errorf("typedb: Insert validation failed: %w", err)
@@ -626,7 +733,15 @@ func Insert[T ModelInterface](ctx context.Context, exec Executor, model T) error
 		primaryKeyColumn = parts[len(parts)-1]
 	}
 
-	columns, values, maskIndices, err := serializeModelFields(model, primaryField.Name)
+	if err := runBeforeInsert(ctx, exec, model); err != nil {
+		return err
+	}
+
+	if err := validateModelFields(model, primaryField.Name, false, getExecutorCodecs(exec)); err != nil {
+		return err
+	}
+
+	columns, values, maskIndices, err := serializeModelFieldsWithOptions(model, primaryField.Name, newSerializeOptions(exec))
 	if err != nil {
 		return fmt.Errorf("typedb: Insert failed to serialize model: %w", err)
 	}
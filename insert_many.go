@@ -0,0 +1,335 @@
+package typedb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// maxSQLServerInsertRows is the most rows SQL Server accepts in one VALUES list.
+const maxSQLServerInsertRows = 1000
+
+// insertManyOrdinalColumn carries each row's index through SQL Server's OUTPUT clause.
+const insertManyOrdinalColumn = "typedb_row"
+
+// maxBindParameters returns the most bind parameters the driver accepts in one statement.
+// SQLite allows 32766 since 3.32.0; the older limit of 999 is used so system libraries work too.
+// SQL Server documents a maximum of 2100 per request; one fewer is used so statements stay below it.
+func maxBindParameters(driverName string) int {
+	switch strings.ToLower(driverName) {
+	case "postgres", "mysql":
+		return 65535
+	case "sqlserver", "mssql":
+		return 2099
+	case "oracle":
+		return 65535
+	default:
+		return 999
+	}
+}
+
+// insertRows is models serialized with a shared column set.
+type insertRows struct {
+	columns     []string
+	rows        [][]any
+	maskColumns []int // Indices of nolog columns
+}
+
+// InsertMany inserts models with multi-row INSERT statements, chunked to stay under the driver's
+// bind parameter limit (65535 on PostgreSQL and MySQL, 999 on SQLite, 2099 and 1000 rows on SQL Server).
+// Oracle has no multi-row VALUES, so each model is inserted with its own statement.
+//
+// Every model is inserted with the same columns: those set (non-zero) on at least one model,
+// excluding the primary key and dbInsert:"false" fields. Models where such a column is zero write
+// their zero value, or NULL for nil pointers and unset Null[T] fields.
+//
+// Generated primary keys are set on each model, from RETURNING in row order (PostgreSQL), from a
+// MERGE whose OUTPUT clause returns each source row's index (SQL Server, where OUTPUT order is not
+// guaranteed), or from the LastInsertId range of each statement (MySQL, SQLite). The ranges assume
+// consecutive auto-increment values, as MySQL assigns for multi-row inserts unless
+// auto_increment_increment is changed.
+//
+// BeforeInsertHook and validate tags are checked for every model before any statement runs, and
+// AfterInsertHook is called for every model after all statements. Chunks are separate statements:
+// run InsertMany in a transaction to insert all models or none. Does nothing for an empty slice.
+//
+// Example:
+//
+//	users := []*User{{Name: "John"}, {Name: "Jane"}}
+//	err := typedb.InsertMany(ctx, db, users)
+//	// Generates: INSERT INTO users (name) VALUES ($1), ($2) RETURNING id
+//	// users[0].ID and users[1].ID are now set
+func InsertMany[T ModelInterface](ctx context.Context, exec Executor, models []T) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	tableName, err := getTableName(models[0])
+	if err != nil {
+		return fmt.Errorf("typedb: InsertMany validation failed: %w", err)
+	}
+
+	if hasDotNotation(models[0]) {
+		return fmt.Errorf("typedb: InsertMany cannot be used with joined models (detected dot notation in db tags)")
+	}
+
+	primaryField, found := findFieldByTag(models[0], "load", "primary")
+	if !found {
+		return fmt.Errorf("typedb: InsertMany requires a field with load:\"primary\" tag")
+	}
+
+	primaryKeyColumn := primaryField.Tag.Get("db")
+	if primaryKeyColumn == "" || primaryKeyColumn == "-" {
+		return fmt.Errorf("typedb: primary key field %s must have a db tag", primaryField.Name)
+	}
+
+	for i, model := range models {
+		if err := runBeforeInsert(ctx, exec, model); err != nil {
+			return fmt.Errorf("typedb: InsertMany model %d: %w", i, err)
+		}
+		if err := validateModelFields(model, primaryField.Name, false, getExecutorCodecs(exec)); err != nil {
+			return fmt.Errorf("typedb: InsertMany model %d: %w", i, err)
+		}
+	}
+
+	data, err := serializeInsertRows(models, primaryField.Name, newSerializeOptions(exec))
+	if err != nil {
+		return fmt.Errorf("typedb: InsertMany failed to serialize models: %w", err)
+	}
+
+	if len(data.columns) == 0 {
+		return fmt.Errorf("typedb: InsertMany requires at least one non-nil field to insert")
+	}
+
+	driverName := getDriverName(exec)
+	chunkSize := maxBindParameters(driverName) / len(data.columns)
+	if driverNameLower := strings.ToLower(driverName); driverNameLower == "oracle" {
+		chunkSize = 1
+	} else if (driverNameLower == "sqlserver" || driverNameLower == "mssql") && chunkSize > maxSQLServerInsertRows {
+		chunkSize = maxSQLServerInsertRows
+	}
+	if chunkSize == 0 {
+		return fmt.Errorf("typedb: InsertMany cannot insert %d columns, more than the %s limit of %d parameters", len(data.columns), driverName, maxBindParameters(driverName))
+	}
+
+	for start := 0; start < len(models); start += chunkSize {
+		end := start + chunkSize
+		if end > len(models) {
+			end = len(models)
+		}
+		if err := insertChunk(ctx, exec, models[start:end], data.rows[start:end], data, driverName, tableName, primaryKeyColumn, primaryField); err != nil {
+			return err
+		}
+	}
+
+	for _, model := range models {
+		if err := runAfterInsert(ctx, exec, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serializeInsertRows serializes models for insertion with the columns set on at least one model,
+// in the order they are first seen.
+func serializeInsertRows[T ModelInterface](models []T, primaryKeyFieldName string, opts serializeOptions) (insertRows, error) {
+	var data insertRows
+	columnIndex := make(map[string]int)
+
+	modelValues := make([]reflect.Value, len(models))
+	for i, model := range models {
+		modelValue := reflect.ValueOf(model)
+		if modelValue.Kind() != reflect.Ptr || modelValue.IsNil() {
+			return insertRows{}, fmt.Errorf("model %d must be a non-nil pointer", i)
+		}
+		modelValues[i] = modelValue.Elem()
+
+		iterateStructFields(modelValues[i].Type(), modelValues[i], primaryKeyFieldName, opts.codecs, func(field reflect.StructField, fieldValue reflect.Value, columnName string) bool {
+			if field.Tag.Get("dbInsert") == "false" || isZeroOrNil(fieldValue) {
+				return true
+			}
+			if _, seen := columnIndex[columnName]; !seen {
+				columnIndex[columnName] = len(data.columns)
+				if field.Tag.Get("nolog") == "true" {
+					data.maskColumns = append(data.maskColumns, len(data.columns))
+				}
+				data.columns = append(data.columns, columnName)
+			}
+			return true
+		})
+	}
+
+	data.rows = make([][]any, len(models))
+	for i, modelValue := range modelValues {
+		row := make([]any, len(data.columns))
+		var err error
+		iterateStructFields(modelValue.Type(), modelValue, primaryKeyFieldName, opts.codecs, func(field reflect.StructField, fieldValue reflect.Value, columnName string) bool {
+			index, ok := columnIndex[columnName]
+			if !ok || field.Tag.Get("dbInsert") == "false" || isNilOnly(fieldValue) {
+				return true
+			}
+			value, valueErr := serializeColumnValue(field, fieldValue, opts)
+			if valueErr != nil {
+				err = fmt.Errorf("model %d: field %s: %w", i, field.Name, valueErr)
+				return false
+			}
+			row[index] = value
+			return true
+		})
+		if err != nil {
+			return insertRows{}, err
+		}
+		data.rows[i] = row
+	}
+
+	return data, nil
+}
+
+// insertChunk inserts one statement's worth of models and sets their primary keys.
+func insertChunk[T ModelInterface](ctx context.Context, exec Executor, models []T, rows [][]any, data insertRows,
+	driverName, tableName, primaryKeyColumn string, primaryField *reflect.StructField) error {
+	columnCount := len(data.columns)
+	values := make([]any, 0, len(rows)*columnCount)
+	var maskIndices []int
+	for r, row := range rows {
+		values = append(values, row...)
+		for _, column := range data.maskColumns {
+			maskIndices = append(maskIndices, r*columnCount+column)
+		}
+	}
+	if len(maskIndices) > 0 {
+		ctx = WithMaskIndices(ctx, maskIndices)
+	}
+
+	quotedTableName, quotedColumns, placeholders := buildInsertQueryParts(driverName, tableName, data.columns, values)
+	driverNameLower := strings.ToLower(driverName)
+	if driverNameLower == "oracle" {
+		return insertOracle(ctx, exec, models[0], driverName, quotedTableName, quotedColumns, placeholders, values, primaryKeyColumn, primaryField)
+	}
+
+	valueLists := make([]string, len(rows))
+	for r := range rows {
+		valueLists[r] = "(" + strings.Join(placeholders[r*columnCount:(r+1)*columnCount], ", ") + ")"
+	}
+
+	switch driverNameLower {
+	case "mysql", "sqlite3":
+		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+			quotedTableName,
+			strings.Join(quotedColumns, ", "),
+			strings.Join(valueLists, ", "))
+
+		result, err := exec.Exec(ctx, query, values...)
+		if err != nil {
+			return fmt.Errorf("typedb: InsertMany failed: %w", err)
+		}
+		if result == nil {
+			return fmt.Errorf("typedb: InsertMany returned nil result")
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("typedb: InsertMany failed to get last insert ID: %w", err)
+		}
+
+		if id == 0 {
+			// The table generated no IDs
+			return nil
+		}
+
+		// MySQL returns the first generated ID of the statement, SQLite the last
+		firstID := id
+		if driverNameLower == "sqlite3" {
+			firstID = id - int64(len(models)) + 1
+		}
+		for i, model := range models {
+			if err := setFieldValue(model, primaryField.Name, firstID+int64(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "sqlserver", "mssql":
+		// OUTPUT order is not guaranteed, so each source row carries its index and keys are matched by it
+		quotedOrdinal := quoteIdentifier(driverName, insertManyOrdinalColumn)
+		sourceColumns := make([]string, len(quotedColumns))
+		for i, column := range quotedColumns {
+			sourceColumns[i] = "source." + column
+		}
+		for r := range valueLists {
+			valueLists[r] = fmt.Sprintf("(%d, %s", r, valueLists[r][1:])
+		}
+		query := fmt.Sprintf("MERGE INTO %s USING (VALUES %s) AS source (%s, %s) ON 1 = 0 WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s) OUTPUT source.%s, INSERTED.%s;",
+			quotedTableName,
+			strings.Join(valueLists, ", "),
+			quotedOrdinal,
+			strings.Join(quotedColumns, ", "),
+			strings.Join(quotedColumns, ", "),
+			strings.Join(sourceColumns, ", "),
+			quotedOrdinal,
+			quoteIdentifier(driverName, primaryKeyColumn))
+
+		returned, err := exec.QueryAll(ctx, query, values...)
+		if err != nil {
+			return fmt.Errorf("typedb: InsertMany failed: %w", err)
+		}
+		if len(returned) != len(models) {
+			return fmt.Errorf("typedb: InsertMany inserted %d models but returned %d primary keys", len(models), len(returned))
+		}
+		assigned := make([]bool, len(models))
+		for _, row := range returned {
+			ordinalValue, ok := row[insertManyOrdinalColumn]
+			if !ok {
+				return fmt.Errorf("typedb: InsertMany OUTPUT clause did not return column %s", insertManyOrdinalColumn)
+			}
+			ordinal, err := deserializeInt(ordinalValue)
+			if err != nil {
+				return fmt.Errorf("typedb: InsertMany OUTPUT clause returned invalid row index: %w", err)
+			}
+			if ordinal < 0 || ordinal >= len(models) || assigned[ordinal] {
+				return fmt.Errorf("typedb: InsertMany OUTPUT clause returned unexpected row index %d", ordinal)
+			}
+			assigned[ordinal] = true
+			idValue, ok := returnedPrimaryKey(row, primaryKeyColumn)
+			if !ok {
+				return fmt.Errorf("typedb: InsertMany OUTPUT clause did not return primary key column %s", primaryKeyColumn)
+			}
+			if err := setFieldValue(models[ordinal], primaryField.Name, idValue); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s%s",
+			quotedTableName,
+			strings.Join(quotedColumns, ", "),
+			strings.Join(valueLists, ", "),
+			buildReturningClause(driverName, primaryKeyColumn))
+
+		returned, err := exec.QueryAll(ctx, query, values...)
+		if err != nil {
+			return fmt.Errorf("typedb: InsertMany failed: %w", err)
+		}
+		if len(returned) != len(models) {
+			return fmt.Errorf("typedb: InsertMany inserted %d models but returned %d primary keys", len(models), len(returned))
+		}
+		for i, model := range models {
+			idValue, ok := returnedPrimaryKey(returned[i], primaryKeyColumn)
+			if !ok {
+				return fmt.Errorf("typedb: InsertMany RETURNING clause did not return primary key column %s", primaryKeyColumn)
+			}
+			if err := setFieldValue(model, primaryField.Name, idValue); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// returnedPrimaryKey looks up the primary key in a returned row, falling back to the upper-cased column name.
+func returnedPrimaryKey(row map[string]any, primaryKeyColumn string) (any, bool) {
+	if value, ok := row[primaryKeyColumn]; ok {
+		return value, true
+	}
+	value, ok := row[strings.ToUpper(primaryKeyColumn)]
+	return value, ok
+}
@@ -0,0 +1,270 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// InsertManyTestUser is a test model for InsertMany
+type InsertManyTestUser struct {
+	Model
+	Nickname  *string `db:"nickname"`
+	CreatedAt string  `db:"created_at" dbInsert:"false"`
+	Email     string  `db:"email"`
+	Token     string  `db:"token" nolog:"true"`
+	ID        int64   `db:"id" load:"primary"`
+	Inserted  bool    `db:"-"`
+}
+
+func (u *InsertManyTestUser) TableName() string {
+	return "users"
+}
+
+func (u *InsertManyTestUser) QueryByID() string {
+	return "SELECT id, email, token, nickname, created_at FROM users WHERE id = ?"
+}
+
+func (u *InsertManyTestUser) BeforeInsert(ctx context.Context, exec Executor) error {
+	if u.Email == "reject@example.com" {
+		return errors.New("rejected")
+	}
+	return nil
+}
+
+func (u *InsertManyTestUser) AfterInsert(ctx context.Context, exec Executor) error {
+	u.Inserted = true
+	return nil
+}
+
+func TestInsertMany_SQLite(t *testing.T) {
+	logger := &testLogger{}
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL, token TEXT, nickname TEXT, created_at TEXT DEFAULT 'now')"); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+
+	// 3 columns per row: 333 rows per statement under SQLite's 999 parameter limit
+	nickname := "nick"
+	users := make([]*InsertManyTestUser, 1000)
+	for i := range users {
+		users[i] = &InsertManyTestUser{Email: fmt.Sprintf("user%d@example.com", i), Token: fmt.Sprintf("secret-%d", i)}
+	}
+	users[1].Nickname = &nickname
+	users[2].Token = ""
+
+	if err := InsertMany(ctx, db, users); err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+	for i, user := range users {
+		if user.ID != int64(i+1) || !user.Inserted {
+			t.Fatalf("Expected model %d to have ID %d and AfterInsert called, got %+v", i, i+1, user)
+		}
+	}
+
+	loaded := &InsertManyTestUser{ID: 3}
+	if err := Load(ctx, db, loaded); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Email != "user2@example.com" || loaded.Token != "" || loaded.Nickname != nil || loaded.CreatedAt != "now" {
+		t.Errorf("Expected zero token, NULL nickname and default created_at, got %+v", loaded)
+	}
+	row, err := db.QueryRowMap(ctx, "SELECT COUNT(*) AS n, COUNT(nickname) AS nicknames FROM users")
+	if err != nil || row["n"] != int64(1000) || row["nicknames"] != int64(1) {
+		t.Errorf("Expected 1000 rows with one nickname, got %v, %v", row, err)
+	}
+
+	var statements int
+	for _, entry := range logger.debugs {
+		if logged := fmt.Sprint(entry); strings.Contains(logged, "INSERT INTO") {
+			statements++
+			if strings.Contains(logged, "secret-") {
+				t.Fatalf("Expected nolog tokens to be masked in every chunk, got %s", logged)
+			}
+		}
+	}
+	if statements != 4 {
+		t.Errorf("Expected 4 chunked statements, got %d", statements)
+	}
+
+	if err := InsertMany(ctx, db, []*InsertManyTestUser{{Email: "ok@example.com"}, {Email: "reject@example.com"}}); err == nil || !strings.Contains(err.Error(), "model 1") {
+		t.Errorf("Expected BeforeInsert error for model 1, got %v", err)
+	}
+	if err := InsertMany(ctx, db, []*InsertManyTestUser{{}, {}}); err == nil || !strings.Contains(err.Error(), "at least one non-nil field") {
+		t.Errorf("Expected empty models error, got %v", err)
+	}
+	if err := InsertMany(ctx, db, []*InsertManyTestUser{}); err != nil {
+		t.Errorf("Expected empty InsertMany to do nothing, got %v", err)
+	}
+}
+
+func TestInsertMany_QueriesPerDriver(t *testing.T) {
+	tests := []struct {
+		driver string
+		query  string
+	}{
+		{driver: "postgres", query: `INSERT INTO "users" ("email", "token") VALUES ($1, $2), ($3, $4) RETURNING "id"`},
+		{driver: "mysql", query: "INSERT INTO `users` (`email`, `token`) VALUES (?, ?), (?, ?)"},
+		{driver: "sqlserver", query: "MERGE INTO [users] USING (VALUES (0, @p1, @p2), (1, @p3, @p4)) AS source ([typedb_row], [email], [token]) ON 1 = 0 WHEN NOT MATCHED THEN INSERT ([email], [token]) VALUES (source.[email], source.[token]) OUTPUT source.[typedb_row], INSERTED.[id];"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock: %v", err)
+			}
+			defer sqlDB.Close()
+
+			logger := &testLogger{}
+			db := NewDBWithLogger(sqlDB, tt.driver, 5*time.Second, logger)
+			switch tt.driver {
+			case "mysql":
+				mock.ExpectExec(regexp.QuoteMeta(tt.query)).WithArgs("a@example.com", "secret-a", "b@example.com", "secret-b").WillReturnResult(sqlmock.NewResult(10, 2))
+			case "sqlserver":
+				mock.ExpectQuery(regexp.QuoteMeta(tt.query)).WithArgs("a@example.com", "secret-a", "b@example.com", "secret-b").WillReturnRows(sqlmock.NewRows([]string{"typedb_row", "id"}).AddRow(int64(0), int64(10)).AddRow(int64(1), int64(11)))
+			default:
+				mock.ExpectQuery(regexp.QuoteMeta(tt.query)).WithArgs("a@example.com", "secret-a", "b@example.com", "secret-b").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(10)).AddRow(int64(11)))
+			}
+
+			users := []*InsertManyTestUser{{Email: "a@example.com", Token: "secret-a"}, {Email: "b@example.com", Token: "secret-b"}}
+			if err := InsertMany(context.Background(), db, users); err != nil {
+				t.Fatalf("InsertMany failed: %v", err)
+			}
+			if users[0].ID != 10 || users[1].ID != 11 {
+				t.Errorf("Expected IDs 10 and 11, got %d and %d", users[0].ID, users[1].ID)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unmet expectations: %v", err)
+			}
+			if logged := fmt.Sprint(logger.debugs); strings.Contains(logged, "secret") || !strings.Contains(logged, "b@example.com") {
+				t.Errorf("Expected only token arguments to be masked, got %s", logged)
+			}
+		})
+	}
+}
+
+func TestInsertMany_SQLServerOutputOutOfOrder(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	db := NewDB(sqlDB, "sqlserver", 5*time.Second)
+	mock.ExpectQuery(regexp.QuoteMeta("MERGE INTO [users]")).
+		WillReturnRows(sqlmock.NewRows([]string{"typedb_row", "id"}).AddRow(int64(2), int64(30)).AddRow(int64(0), int64(10)).AddRow(int64(1), int64(20)))
+
+	users := []*InsertManyTestUser{{Email: "a@example.com"}, {Email: "b@example.com"}, {Email: "c@example.com"}}
+	if err := InsertMany(context.Background(), db, users); err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+	if users[0].ID != 10 || users[1].ID != 20 || users[2].ID != 30 {
+		t.Errorf("Expected IDs 10, 20 and 30, got %d, %d and %d", users[0].ID, users[1].ID, users[2].ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestInsertMany_SQLServerParameterLimit(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// Three parameters per row: 699 rows (2097 parameters) fit under the 2099 limit, the 700th does not
+	firstIDs := sqlmock.NewRows([]string{"typedb_row", "id"})
+	for r := 0; r < 699; r++ {
+		firstIDs.AddRow(int64(r), int64(r+1))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("(698, @p2095, @p2096, @p2097)) AS source")).WillReturnRows(firstIDs)
+	mock.ExpectQuery(regexp.QuoteMeta("USING (VALUES (0, @p1, @p2, @p3)) AS source")).
+		WillReturnRows(sqlmock.NewRows([]string{"typedb_row", "id"}).AddRow(int64(0), int64(700)))
+
+	nickname := "nick"
+	users := make([]*InsertManyTestUser, 700)
+	for i := range users {
+		users[i] = &InsertManyTestUser{Email: fmt.Sprintf("user%d@example.com", i), Token: "secret", Nickname: &nickname}
+	}
+	db := NewDB(sqlDB, "sqlserver", 5*time.Second)
+	if err := InsertMany(context.Background(), db, users); err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+	if users[698].ID != 699 || users[699].ID != 700 {
+		t.Errorf("Expected IDs 699 and 700 at the chunk boundary, got %d and %d", users[698].ID, users[699].ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestInsertMany_SQLServerDuplicateRowIndex(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	db := NewDB(sqlDB, "sqlserver", 5*time.Second)
+	mock.ExpectQuery(regexp.QuoteMeta("MERGE INTO [users]")).
+		WillReturnRows(sqlmock.NewRows([]string{"typedb_row", "id"}).AddRow(int64(0), int64(10)).AddRow(int64(0), int64(11)))
+
+	users := []*InsertManyTestUser{{Email: "a@example.com"}, {Email: "b@example.com"}}
+	err = InsertMany(context.Background(), db, users)
+	if err == nil || !strings.Contains(err.Error(), "unexpected row index 0") {
+		t.Errorf("Expected unexpected row index error, got %v", err)
+	}
+}
+
+func TestInsertMany_Oracle(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	query := regexp.QuoteMeta(`INSERT INTO "USERS" ("EMAIL") VALUES (:1) RETURNING "ID" INTO :2`)
+	mock.ExpectExec(query).WithArgs("a@example.com", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs("b@example.com", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	db := &oracleTestWrapper{DB: NewDB(sqlDB, "oracle", 5*time.Second), testID: 42}
+	users := []*InsertManyTestUser{{Email: "a@example.com"}, {Email: "b@example.com"}}
+	if err := InsertMany(context.Background(), db, users); err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+	if users[0].ID != 42 || users[1].ID != 42 {
+		t.Errorf("Expected IDs from RETURNING INTO, got %d and %d", users[0].ID, users[1].ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestMaxBindParameters(t *testing.T) {
+	tests := map[string]int{
+		"postgres":  65535,
+		"mysql":     65535,
+		"sqlite3":   999,
+		"sqlserver": 2099,
+		"mssql":     2099,
+		"oracle":    65535,
+	}
+	for driver, want := range tests {
+		if got := maxBindParameters(driver); got != want {
+			t.Errorf("%s: expected %d, got %d", driver, want, got)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package typedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// dbTypeJSON is the dbType tag value for fields stored as JSON (json, jsonb, JSON or text columns).
+const dbTypeJSON = "json"
+
+// jsonColumnsCache maps a struct type to the set of db tags of its dbType:"json" fields.
+var jsonColumnsCache sync.Map // map[reflect.Type]map[string]bool
+
+// isJSONField reports whether a field is tagged dbType:"json".
+func isJSONField(field reflect.StructField) bool {
+	return field.Tag.Get("dbType") == dbTypeJSON
+}
+
+// jsonColumns returns the db tags of dbType:"json" fields on a struct type, including embedded structs.
+func jsonColumns(t reflect.Type) map[string]bool {
+	if cached, ok := jsonColumnsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+
+	columns := make(map[string]bool)
+	var collect func(reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					collect(embeddedType)
+					continue
+				}
+			}
+			dbTag := field.Tag.Get("db")
+			if dbTag == "" || dbTag == "-" || !isJSONField(field) {
+				continue
+			}
+			columns[dbTag] = true
+		}
+	}
+	collect(t)
+
+	jsonColumnsCache.Store(t, columns)
+	return columns
+}
+
+// decodeJSONColumn decodes a JSON column value into a field.
+// Accepts JSON text as string or []byte, and driver-native decoded JSON (maps, slices, numbers, ...),
+// which is re-encoded and decoded into the field type. NULL resets the field.
+func decodeJSONColumn(fieldValuePtr reflect.Value, column string, value any) error {
+	fieldElem := fieldValuePtr.Elem()
+
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		fieldElem.Set(reflect.Zero(fieldElem.Type()))
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("typedb: column %q: cannot re-encode %T as JSON: %w", column, value, err)
+		}
+		data = encoded
+	}
+
+	// Decode into a fresh value so stale map entries and slice elements don't survive
+	decoded := reflect.New(fieldElem.Type())
+	if err := json.Unmarshal(data, decoded.Interface()); err != nil {
+		return fmt.Errorf("typedb: column %q: invalid JSON for %v: %w", column, fieldElem.Type(), err)
+	}
+	fieldElem.Set(decoded.Elem())
+	return nil
+}
+
+// encodeJSONColumn encodes a field value as JSON text for a dbType:"json" column.
+// Nil pointers, maps and slices encode as NULL.
+func encodeJSONColumn(field reflect.StructField, v reflect.Value) (any, error) {
+	if isNilOnly(v) {
+		return nil, nil
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode %v as JSON: %w", field.Type, err)
+	}
+	return string(data), nil
+}
+
+// serializeColumnValue returns the value to bind for a struct field, unwrapping Null[T] and applying
+// its dbType and timeFormat tags before the type-based handling in serializeFieldValue.
+func serializeColumnValue(field reflect.StructField, v reflect.Value, opts serializeOptions) (any, error) {
+	// Null[T] writes NULL unless valid, then V as for a T field
+	if value, valid, _, ok := asNullSource(v); ok {
+		if !valid {
+			return nil, nil
+		}
+		return serializeColumnValue(field, value, opts)
+	}
+	if isJSONField(field) {
+		return encodeJSONColumn(field, v)
+	}
+	if _, hasCodec := lookupCodec(opts.codecs, timeType); !hasCodec {
+		if value, ok := encodeTimeColumn(field, v, opts.times); ok {
+			return value, nil
+		}
+	}
+	return serializeFieldValue(v, opts)
+}
+
+// validateDBTypeFields checks dbType tags name a supported column type.
+func validateDBTypeFields(t reflect.Type) []string {
+	var errors []string
+
+	var check func(reflect.Type)
+	check = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					check(embeddedType)
+					continue
+				}
+			}
+			dbType, ok := field.Tag.Lookup("dbType")
+			if !ok {
+				continue
+			}
+			if dbType != dbTypeJSON {
+				errors = append(errors, fmt.Sprintf("field %s: unsupported dbType %q (supported: %q)", field.Name, dbType, dbTypeJSON))
+			}
+		}
+	}
+
+	check(t)
+	return errors
+}
@@ -0,0 +1,208 @@
+package typedb
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// JSONTestSettings is a nested struct stored in a JSON column
+type JSONTestSettings struct {
+	Theme         string   `json:"theme"`
+	Notifications []string `json:"notifications"`
+	FontSize      int      `json:"font_size"`
+}
+
+// JSONTestUser is a test model for dbType:"json" tests
+type JSONTestUser struct {
+	Model
+	Labels   map[string]int    `db:"labels" dbType:"json"`
+	Profile  *JSONTestSettings `db:"profile" dbType:"json"`
+	Name     string            `db:"name"`
+	Tags     []string          `db:"tags" dbType:"json"`
+	Settings JSONTestSettings  `db:"settings" dbType:"json"`
+	ID       int64             `db:"id" load:"primary"`
+}
+
+func (u *JSONTestUser) TableName() string {
+	return "users"
+}
+
+func (u *JSONTestUser) QueryByID() string {
+	return "SELECT id, name, settings, profile, tags, labels FROM users WHERE id = ?"
+}
+
+// JSONTestBadType uses an unsupported dbType
+type JSONTestBadType struct {
+	Model
+	Data string `db:"data" dbType:"xml"`
+	ID   int64  `db:"id"`
+}
+
+func TestJSONColumn_Deserialize(t *testing.T) {
+	settings := `{"theme":"dark","notifications":["email"],"font_size":14}`
+	tests := []struct {
+		value any
+		name  string
+	}{
+		{name: "string", value: settings},
+		{name: "bytes", value: []byte(settings)},
+		{name: "driver-native", value: map[string]any{"theme": "dark", "notifications": []any{"email"}, "font_size": float64(14)}},
+	}
+
+	want := JSONTestSettings{Theme: "dark", Notifications: []string{"email"}, FontSize: 14}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := deserializeForType[*JSONTestUser](map[string]any{"settings": tt.value, "profile": tt.value})
+			if err != nil {
+				t.Fatalf("deserialize failed: %v", err)
+			}
+			if !reflect.DeepEqual(user.Settings, want) {
+				t.Errorf("Expected Settings %+v, got %+v", want, user.Settings)
+			}
+			if user.Profile == nil || !reflect.DeepEqual(*user.Profile, want) {
+				t.Errorf("Expected Profile %+v, got %+v", want, user.Profile)
+			}
+		})
+	}
+
+	user, err := deserializeForType[*JSONTestUser](map[string]any{"tags": `["a","b"]`, "labels": `{"x":1}`, "profile": nil})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if !reflect.DeepEqual(user.Tags, []string{"a", "b"}) || user.Labels["x"] != 1 || user.Profile != nil {
+		t.Errorf("Unexpected result: %+v", user)
+	}
+}
+
+func TestJSONColumn_DeserializeReplacesExisting(t *testing.T) {
+	user := &JSONTestUser{Labels: map[string]int{"stale": 1}}
+	if err := deserialize(map[string]any{"labels": `{"fresh":2}`}, user); err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if !reflect.DeepEqual(user.Labels, map[string]int{"fresh": 2}) {
+		t.Errorf("Expected stale keys to be dropped, got %v", user.Labels)
+	}
+}
+
+func TestJSONColumn_DeserializeErrorNamesColumn(t *testing.T) {
+	_, err := deserializeForType[*JSONTestUser](map[string]any{"settings": `{"theme":`})
+	if err == nil || !strings.Contains(err.Error(), `column "settings"`) {
+		t.Errorf("Expected error naming the column, got %v", err)
+	}
+}
+
+func TestJSONColumn_Serialize(t *testing.T) {
+	user := &JSONTestUser{
+		Name:     "Alice",
+		Settings: JSONTestSettings{Theme: "light"},
+		Tags:     []string{"a"},
+	}
+
+	columns, values, _, err := serializeModelFields(user, "ID")
+	if err != nil {
+		t.Fatalf("serializeModelFields failed: %v", err)
+	}
+
+	wantColumns := []string{"name", "tags", "settings"}
+	wantValues := []any{"Alice", `["a"]`, `{"theme":"light","notifications":null,"font_size":0}`}
+	if !reflect.DeepEqual(columns, wantColumns) {
+		t.Errorf("Expected columns %v, got %v", wantColumns, columns)
+	}
+	if !reflect.DeepEqual(values, wantValues) {
+		t.Errorf("Expected values %v, got %v", wantValues, values)
+	}
+}
+
+func TestJSONColumn_PartialUpdate(t *testing.T) {
+	withModelOptions(t, reflect.TypeOf(JSONTestUser{}), ModelOptions{PartialUpdate: true})
+
+	user, err := deserializeForType[*JSONTestUser](map[string]any{
+		"id":       int64(1),
+		"name":     "Alice",
+		"settings": `{"theme":"dark","notifications":["email"],"font_size":14}`,
+		"labels":   map[string]any{"x": float64(1)},
+	})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+
+	changed, err := getChangedFields(user, "ID")
+	if err != nil {
+		t.Fatalf("getChangedFields failed: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("Expected no changes after load, got %v", changed)
+	}
+
+	user.Settings.Notifications = append(user.Settings.Notifications, "sms")
+	changed, err = getChangedFields(user, "ID")
+	if err != nil {
+		t.Fatalf("getChangedFields failed: %v", err)
+	}
+	if !reflect.DeepEqual(changed, map[string]bool{"settings": true}) {
+		t.Errorf("Expected only settings to change, got %v", changed)
+	}
+
+	columns, values, _, _, err := serializeModelFieldsForUpdate(user, "ID", "sqlite3", changed)
+	if err != nil {
+		t.Fatalf("serializeModelFieldsForUpdate failed: %v", err)
+	}
+	if !reflect.DeepEqual(columns, []string{"settings"}) {
+		t.Fatalf("Expected settings column, got %v", columns)
+	}
+	var written JSONTestSettings
+	if err := json.Unmarshal([]byte(values[0].(string)), &written); err != nil {
+		t.Fatalf("Expected JSON text, got %v: %v", values[0], err)
+	}
+	if !reflect.DeepEqual(written.Notifications, []string{"email", "sms"}) {
+		t.Errorf("Unexpected written settings %+v", written)
+	}
+}
+
+func TestJSONColumn_SQLiteRoundTrip(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, settings TEXT, profile TEXT, tags TEXT, labels BLOB)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	user := &JSONTestUser{
+		Name:     "Alice",
+		Settings: JSONTestSettings{Theme: "dark", FontSize: 12},
+		Profile:  &JSONTestSettings{Theme: "light"},
+		Labels:   map[string]int{"vip": 1},
+	}
+	if err := Insert(ctx, db, user); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	loaded, err := QueryOne[*JSONTestUser](ctx, db, "SELECT id, name, settings, profile, tags, labels FROM users WHERE id = ?", user.ID)
+	if err != nil {
+		t.Fatalf("QueryOne failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Settings, user.Settings) || !reflect.DeepEqual(loaded.Profile, user.Profile) || !reflect.DeepEqual(loaded.Labels, user.Labels) {
+		t.Errorf("Round trip mismatch: %+v", loaded)
+	}
+	if loaded.Tags != nil {
+		t.Errorf("Expected NULL tags, got %v", loaded.Tags)
+	}
+}
+
+func TestValidateModel_DBType(t *testing.T) {
+	if err := ValidateModel(&JSONTestUser{}); err != nil {
+		t.Errorf("Expected valid model, got %v", err)
+	}
+
+	err := ValidateModel(&JSONTestBadType{})
+	if err == nil || !strings.Contains(err.Error(), `unsupported dbType "xml"`) {
+		t.Errorf("Expected dbType error, got %v", err)
+	}
+}
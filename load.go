@@ -32,7 +32,10 @@ func Load[T ModelInterface](ctx context.Context, exec Executor, model T) error {
 		return fmt.Errorf("typedb: primary key field %s is not set", primaryField.Name)
 	}
 
-	fieldValue := fieldValueReflect.Interface()
+	fieldValue, err := serializeFieldValue(fieldValueReflect, newSerializeOptions(exec))
+	if err != nil {
+		return fmt.Errorf("typedb: failed to serialize primary key value: %w", err)
+	}
 
 	if primaryField.Tag.Get("nolog") == "true" {
 		ctx = WithMaskIndices(ctx, []int{0})
@@ -77,7 +80,10 @@ func LoadByField[T ModelInterface](ctx context.Context, exec Executor, model T,
 		return fmt.Errorf("typedb: field %s is not set", fieldName)
 	}
 
-	fieldValue := fieldValueReflect.Interface()
+	fieldValue, err := serializeFieldValue(fieldValueReflect, newSerializeOptions(exec))
+	if err != nil {
+		return fmt.Errorf("typedb: failed to serialize field %s value: %w", fieldName, err)
+	}
 
 	modelType := getModelType(model)
 	if modelType.Kind() == reflect.Ptr {
@@ -141,6 +147,7 @@ func LoadByComposite[T ModelInterface](ctx context.Context, exec Executor, model
 
 	// Get values for all fields in composite key and check for nolog tags
 	fieldValues := make([]any, len(fieldNames))
+	serializeOpts := newSerializeOptions(exec)
 	var maskIndices []int
 	modelType := getModelType(model)
 	if modelType.Kind() == reflect.Ptr {
@@ -157,7 +164,10 @@ func LoadByComposite[T ModelInterface](ctx context.Context, exec Executor, model
 			return fmt.Errorf("typedb: composite key field %s is not set", fieldName)
 		}
 
-		fieldValues[i] = valueReflect.Interface()
+		fieldValues[i], err = serializeFieldValue(valueReflect, serializeOpts)
+		if err != nil {
+			return fmt.Errorf("typedb: failed to serialize field %s value: %w", fieldName, err)
+		}
 
 		// Check if field has nolog tag
 		field, found := modelType.FieldByName(fieldName)
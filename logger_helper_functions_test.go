@@ -137,7 +137,7 @@ func TestQueryAllHelperMasking(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).
 				AddRow(1, "John", "john@example.com"))
 
-		_, err := queryAllHelper(ctx, db, logger, 5*time.Second, true, true, query, args...)
+		_, err := queryAllHelper(ctx, db, logger, 5*time.Second, true, true, false, query, args...)
 		if err != nil {
 			t.Fatalf("queryAllHelper failed: %v", err)
 		}
@@ -182,7 +182,7 @@ func TestQueryAllHelperMasking(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).
 				AddRow(1, "John", "john@example.com"))
 
-		_, err := queryAllHelper(maskedCtx, db, logger, 5*time.Second, true, true, query, args...)
+		_, err := queryAllHelper(maskedCtx, db, logger, 5*time.Second, true, true, false, query, args...)
 		if err != nil {
 			t.Fatalf("queryAllHelper failed: %v", err)
 		}
@@ -242,7 +242,7 @@ func TestQueryRowMapHelperMasking(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).
 				AddRow(123, "John", "john@example.com"))
 
-		_, err := queryRowMapHelper(ctx, db, logger, 5*time.Second, true, true, query, args...)
+		_, err := queryRowMapHelper(ctx, db, logger, 5*time.Second, true, true, false, query, args...)
 		if err != nil {
 			t.Fatalf("queryRowMapHelper failed: %v", err)
 		}
@@ -287,7 +287,7 @@ func TestQueryRowMapHelperMasking(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).
 				AddRow(123, "John", "john@example.com"))
 
-		_, err := queryRowMapHelper(maskedCtx, db, logger, 5*time.Second, true, true, query, args...)
+		_, err := queryRowMapHelper(maskedCtx, db, logger, 5*time.Second, true, true, false, query, args...)
 		if err != nil {
 			t.Fatalf("queryRowMapHelper failed: %v", err)
 		}
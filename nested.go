@@ -0,0 +1,247 @@
+package typedb
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// nestedField describes a struct, *struct or slice of struct field that is hydrated from prefixed columns.
+type nestedField struct {
+	structType reflect.Type // The struct type, with any slice and pointer removed
+	tag        string       // The db tag, used as the column prefix
+	pointer    bool         // True for *struct fields and []*struct elements
+	slice      bool         // True for slice fields, which only QueryAggregate fills
+}
+
+// nestedFieldsCache maps a struct type to its nested struct fields.
+var nestedFieldsCache sync.Map // map[reflect.Type][]nestedField
+
+// nestedStructFields returns the nested struct fields of a struct type, including embedded structs.
+// Registered codecs are not considered here; see isNestedStructField.
+func nestedStructFields(t reflect.Type) []nestedField {
+	if cached, ok := nestedFieldsCache.Load(t); ok {
+		return cached.([]nestedField)
+	}
+
+	var fields []nestedField
+	var collect func(reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					collect(embeddedType)
+					continue
+				}
+			}
+			dbTag := field.Tag.Get("db")
+			if dbTag == "" || dbTag == "-" || dbTag == collectColumnsTag || !isNestedStructType(field) {
+				continue
+			}
+			nested := nestedField{structType: field.Type, tag: dbTag}
+			if nested.structType.Kind() == reflect.Slice {
+				nested.structType = nested.structType.Elem()
+				nested.slice = true
+			}
+			if nested.structType.Kind() == reflect.Ptr {
+				nested.structType = nested.structType.Elem()
+				nested.pointer = true
+			}
+			fields = append(fields, nested)
+		}
+	}
+	collect(t)
+
+	nestedFieldsCache.Store(t, fields)
+	return fields
+}
+
+// isNestedStructType reports whether a field holds a struct (or a pointer or slice of them) whose
+// own db-tagged fields map to columns, as opposed to a struct stored in a single column
+// (time.Time, Decimal, Null, sql.Scanner or driver.Valuer implementations, built-in codec types
+// and dbType:"json" fields).
+func isNestedStructType(field reflect.StructField) bool {
+	if isJSONField(field) {
+		return false
+	}
+	return isNestedType(nestedElemType(field.Type))
+}
+
+// nestedElemType removes a slice and then a pointer from t.
+func nestedElemType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isNestedType reports whether t is a struct type with db-tagged fields that is not stored in a single column.
+func isNestedType(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+	if _, ok := builtinCodec(t); ok {
+		return false
+	}
+	if reflect.PointerTo(t).Implements(scannerType) || t.Implements(valuerType) || reflect.PointerTo(t).Implements(valuerType) {
+		return false
+	}
+	return len(orderedDBTags(t)) > 0
+}
+
+// isNestedStructField reports whether a field is a nested struct field that Insert and Update skip.
+// A struct type with a codec in dbCodecs or registered globally is a single column instead.
+func isNestedStructField(field reflect.StructField, dbCodecs *codecRegistry) bool {
+	if !isNestedStructType(field) {
+		return false
+	}
+	_, hasCodec := lookupCodec(dbCodecs, nestedElemType(field.Type))
+	return !hasCodec
+}
+
+// nestedColumns extracts the columns of row that belong to a nested struct field: columns named
+// "<tag>.<column>" and "<tag>_<column>", keyed by <column>. The underscore form is only used for
+// columns of the nested struct (or of its own nested structs), and the dot form takes precedence.
+func nestedColumns(row map[string]any, field nestedField) (columns map[string]any, consumed []string) {
+	dotPrefix := field.tag + "."
+	underscorePrefix := field.tag + "_"
+
+	for key, value := range row {
+		if strings.HasPrefix(key, dotPrefix) {
+			if columns == nil {
+				columns = make(map[string]any)
+			}
+			columns[key[len(dotPrefix):]] = value
+			consumed = append(consumed, key)
+		}
+	}
+
+	var tags map[string]bool
+	for key, value := range row {
+		if !strings.HasPrefix(key, underscorePrefix) {
+			continue
+		}
+		column := key[len(underscorePrefix):]
+		if _, ok := columns[column]; ok {
+			continue
+		}
+		if tags == nil {
+			tags = make(map[string]bool)
+			for _, tag := range orderedDBTags(field.structType) {
+				tags[tag] = true
+			}
+		}
+		if !tags[column] && !hasNestedPrefix(field.structType, column) {
+			continue
+		}
+		if columns == nil {
+			columns = make(map[string]any)
+		}
+		columns[column] = value
+		consumed = append(consumed, key)
+	}
+
+	return columns, consumed
+}
+
+// hasNestedPrefix reports whether column is prefixed by the tag of one of t's nested struct fields.
+func hasNestedPrefix(t reflect.Type, column string) bool {
+	for _, nested := range nestedStructFields(t) {
+		if strings.HasPrefix(column, nested.tag+".") || strings.HasPrefix(column, nested.tag+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// hydrateNestedStructs deserializes the prefixed columns of row into the nested struct fields of t.
+// The columns of slice fields are consumed without being deserialized; QueryAggregate fills them.
+// A nested field is skipped when row has a column named exactly like its tag, which is then
+// deserialized as a single value. *struct fields are set to nil when all their columns are NULL.
+//
+// Returns the row to use for column policy checks, in which the consumed prefixed columns are
+// replaced by the tags of the hydrated fields.
+func hydrateNestedStructs(row map[string]any, t reflect.Type, fieldMap map[string]reflect.Value, opts deserializeOptions) (map[string]any, error) {
+	policyRow := row
+	copied := false
+	for _, field := range nestedStructFields(t) {
+		if _, ok := row[field.tag]; ok {
+			continue
+		}
+		if _, hasCodec := lookupCodec(opts.codecs, field.structType); hasCodec {
+			continue
+		}
+		fieldPtr, ok := fieldMap[field.tag]
+		if !ok {
+			continue
+		}
+
+		columns, consumed := nestedColumns(row, field)
+		if len(consumed) == 0 {
+			continue
+		}
+
+		if !field.slice {
+			if err := hydrateNestedStruct(fieldPtr, field, columns, opts); err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.tag, err)
+			}
+		}
+
+		if !copied {
+			policyRow = make(map[string]any, len(row))
+			for key, value := range row {
+				policyRow[key] = value
+			}
+			copied = true
+		}
+		for _, key := range consumed {
+			if _, mapped := fieldMap[key]; !mapped {
+				delete(policyRow, key)
+			}
+		}
+		policyRow[field.tag] = true
+	}
+	return policyRow, nil
+}
+
+// hydrateNestedStruct deserializes columns into the nested field pointed to by fieldPtr.
+func hydrateNestedStruct(fieldPtr reflect.Value, field nestedField, columns map[string]any, opts deserializeOptions) error {
+	fieldElem := fieldPtr.Elem()
+	if !field.pointer {
+		_, _, _, err := deserializeStructFields(columns, fieldPtr, opts)
+		return err
+	}
+
+	if allNull(columns) {
+		fieldElem.Set(reflect.Zero(fieldElem.Type()))
+		return nil
+	}
+
+	if fieldElem.IsNil() {
+		fieldElem.Set(reflect.New(field.structType))
+	}
+	_, _, _, err := deserializeStructFields(columns, fieldElem, opts)
+	return err
+}
+
+// allNull reports whether every value in columns is NULL.
+func allNull(columns map[string]any) bool {
+	for _, value := range columns {
+		if value != nil {
+			return false
+		}
+	}
+	return true
+}
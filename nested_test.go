@@ -0,0 +1,184 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// NestedTestAddress is a nested type that is neither registered nor a Model
+type NestedTestAddress struct {
+	City string `db:"city"`
+	Zip  string `db:"zip"`
+}
+
+// NestedTestUser is a nested type with its own nested struct
+type NestedTestUser struct {
+	Address NestedTestAddress `db:"address"`
+	Name    string            `db:"name"`
+	ID      int64             `db:"id"`
+}
+
+// NestedTestPost is a test model hydrating nested structs from joined columns
+type NestedTestPost struct {
+	Model
+	Author    NestedTestUser  `db:"author"`
+	Editor    *NestedTestUser `db:"editor"`
+	CreatedAt time.Time       `db:"created_at"`
+	Title     string          `db:"title"`
+	ID        int64           `db:"id" load:"primary"`
+}
+
+func (p *NestedTestPost) TableName() string {
+	return "posts"
+}
+
+func (p *NestedTestPost) QueryByID() string {
+	return "SELECT id, title FROM posts WHERE id = ?"
+}
+
+func TestIsNestedStructType(t *testing.T) {
+	fields := map[string]bool{"Author": true, "Editor": true, "CreatedAt": false, "Title": false}
+	postType := reflect.TypeOf(NestedTestPost{})
+	for name, want := range fields {
+		field, _ := postType.FieldByName(name)
+		if got := isNestedStructType(field); got != want {
+			t.Errorf("isNestedStructType(%s) = %v, want %v", name, got, want)
+		}
+	}
+
+	nullField, _ := reflect.TypeOf(NullTestUser{}).FieldByName("Balance")
+	if isNestedStructType(nullField) {
+		t.Error("Expected Null[Decimal] to be a single column")
+	}
+}
+
+func TestDeserialize_NestedStructs(t *testing.T) {
+	post, err := deserializeForType[*NestedTestPost](map[string]any{
+		"id":                  int64(1),
+		"title":               "Hello",
+		"author.id":           int64(2),
+		"author.name":         "Alice",
+		"author.address.city": "Paris",
+		"author_address_zip":  "75001",
+		"editor_id":           int64(3),
+		"editor_name":         "Bob",
+		"editor_nickname":     "bobby",
+	})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+
+	want := NestedTestUser{ID: 2, Name: "Alice", Address: NestedTestAddress{City: "Paris", Zip: "75001"}}
+	if post.Author != want {
+		t.Errorf("Expected author %+v, got %+v", want, post.Author)
+	}
+	if post.Editor == nil || post.Editor.ID != 3 || post.Editor.Name != "Bob" {
+		t.Errorf("Unexpected editor %+v", post.Editor)
+	}
+}
+
+func TestDeserialize_NestedPointerAllNull(t *testing.T) {
+	post := &NestedTestPost{Editor: &NestedTestUser{ID: 9}}
+	err := deserialize(map[string]any{"id": int64(1), "editor.id": nil, "editor.name": nil}, post)
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if post.Editor != nil {
+		t.Errorf("Expected nil editor for an unmatched LEFT JOIN, got %+v", post.Editor)
+	}
+}
+
+func TestDeserialize_NestedColumnPolicy(t *testing.T) {
+	row := map[string]any{"id": int64(1), "title": "Hello", "created_at": time.Now(), "author.id": int64(2), "editor.id": int64(3)}
+	_, err := deserializeForTypeWithOptions[*NestedTestPost](row, deserializeOptions{dbColumnPolicy: ColumnPolicyErrorOnUnknown | ColumnPolicyErrorOnMissing})
+	if err != nil {
+		t.Errorf("Expected prefixed columns to satisfy the column policy, got %v", err)
+	}
+
+	row["author.extra"] = 1
+	_, err = deserializeForTypeWithOptions[*NestedTestPost](row, deserializeOptions{dbColumnPolicy: ColumnPolicyErrorOnUnknown})
+	if err != nil {
+		t.Errorf("Expected unknown nested columns to be consumed by the nested field, got %v", err)
+	}
+
+	_, err = deserializeForTypeWithOptions[*NestedTestPost](map[string]any{"id": int64(1)}, deserializeOptions{dbColumnPolicy: ColumnPolicyErrorOnMissing})
+	if !errors.Is(err, ErrMissingColumn) {
+		t.Errorf("Expected ErrMissingColumn for nested fields without columns, got %v", err)
+	}
+}
+
+func TestSerialize_SkipsNestedStructs(t *testing.T) {
+	post := &NestedTestPost{Title: "Hello", Author: NestedTestUser{ID: 2}, Editor: &NestedTestUser{ID: 3}}
+	columns, _, _, err := serializeModelFields(post, "ID")
+	if err != nil {
+		t.Fatalf("serializeModelFields failed: %v", err)
+	}
+	if !reflect.DeepEqual(columns, []string{"created_at", "title"}) {
+		t.Errorf("Expected nested structs to be skipped, got %v", columns)
+	}
+}
+
+func TestSerialize_NestedStructWithDBCodec(t *testing.T) {
+	db := NewDB(nil, "postgres", 0)
+	RegisterDBCodec(db,
+		func(v any) (NestedTestUser, error) { return NestedTestUser{ID: v.(int64)}, nil },
+		func(u NestedTestUser) (any, error) { return u.ID, nil },
+	)
+
+	post := &NestedTestPost{Title: "Hello", Author: NestedTestUser{ID: 2}}
+	columns, values, _, err := serializeModelFieldsWithOptions(post, "ID", newSerializeOptions(db))
+	if err != nil {
+		t.Fatalf("serializeModelFieldsWithOptions failed: %v", err)
+	}
+	if !reflect.DeepEqual(columns, []string{"author", "created_at", "title"}) || values[0] != int64(2) {
+		t.Errorf("Expected the DB codec field to be written as one column, got %v %v", columns, values)
+	}
+}
+
+func TestNested_SQLiteLeftJoin(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, city TEXT)",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT, author_id INTEGER, editor_id INTEGER, created_at TIMESTAMP)",
+		"INSERT INTO users (id, name, city) VALUES (1, 'Alice', 'Paris'), (2, 'Bob', 'Rome')",
+		"INSERT INTO posts (id, title, author_id, editor_id) VALUES (10, 'Edited', 1, 2), (11, 'Draft', 2, NULL)",
+	} {
+		if _, err := db.Exec(ctx, stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+
+	posts, err := QueryAll[*NestedTestPost](ctx, db, `
+		SELECT p.id, p.title,
+		       a.id AS author_id, a.name AS author_name, a.city AS author_address_city,
+		       e.id AS "editor.id", e.name AS "editor.name"
+		FROM posts p
+		JOIN users a ON a.id = p.author_id
+		LEFT JOIN users e ON e.id = p.editor_id
+		ORDER BY p.id`)
+	if err != nil {
+		t.Fatalf("QueryAll failed: %v", err)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("Expected 2 posts, got %d", len(posts))
+	}
+	if posts[0].Author.Name != "Alice" || posts[0].Author.Address.City != "Paris" || posts[0].Editor == nil || posts[0].Editor.Name != "Bob" {
+		t.Errorf("Unexpected first post %+v (editor %+v)", posts[0], posts[0].Editor)
+	}
+	if posts[1].Author.ID != 2 || posts[1].Editor != nil {
+		t.Errorf("Expected second post without editor, got %+v", posts[1])
+	}
+
+	if err := Insert(ctx, db, &NestedTestPost{Title: "New", Author: NestedTestUser{ID: 1}}); err != nil {
+		t.Errorf("Expected Insert to skip nested structs, got %v", err)
+	}
+}
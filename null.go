@@ -0,0 +1,175 @@
+package typedb
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+)
+
+// Null is a nullable column value with three states, which Insert and Update treat differently:
+//   - unset (the zero value): the column is omitted
+//   - NULL (NullOf, or NULL read from the database): the column is written as NULL
+//   - valid (NewNull, or Valid set to true): V is written, even if it is a zero value
+//
+// Null implements sql.Scanner, driver.Valuer, json.Marshaler and json.Unmarshaler.
+// The value field is named V, as in sql.Null, because Value is the driver.Valuer method.
+// Query deserialization populates V exactly as it would a T field, including codecs,
+// time settings and timeFormat tags.
+//
+// Example:
+//
+//	type User struct {
+//	    typedb.Model
+//	    ID     int64                 `db:"id" load:"primary"`
+//	    Logins typedb.Null[int]      `db:"logins"`
+//	    Bio    typedb.Null[string]   `db:"bio"`
+//	}
+//
+//	user.Logins = typedb.NewNull(0)       // writes 0
+//	user.Bio = typedb.NullOf[string]()    // writes NULL
+type Null[T any] struct {
+	V     T    // The value; meaningful only when Valid is true
+	Valid bool // True when V holds a non-NULL value
+	null  bool // True when explicitly NULL, distinguishing NULL from unset
+}
+
+// NewNull returns a valid Null holding v.
+func NewNull[T any](v T) Null[T] {
+	return Null[T]{V: v, Valid: true}
+}
+
+// NullOf returns a Null that is explicitly NULL.
+func NullOf[T any]() Null[T] {
+	return Null[T]{null: true}
+}
+
+// IsNull reports whether n is explicitly NULL.
+func (n Null[T]) IsNull() bool {
+	return !n.Valid && n.null
+}
+
+// IsUnset reports whether n is neither valid nor explicitly NULL.
+func (n Null[T]) IsUnset() bool {
+	return !n.Valid && !n.null
+}
+
+// Get returns V and whether it is valid.
+func (n Null[T]) Get() (T, bool) {
+	return n.V, n.Valid
+}
+
+// ValueOr returns V if valid, otherwise fallback.
+func (n Null[T]) ValueOr(fallback T) T {
+	if n.Valid {
+		return n.V
+	}
+	return fallback
+}
+
+// Scan implements sql.Scanner. NULL makes n explicitly NULL; other values are converted to T
+// with the same rules used for T fields.
+func (n *Null[T]) Scan(src any) error {
+	if src == nil {
+		*n = NullOf[T]()
+		return nil
+	}
+	var v T
+	if err := deserializeToFieldValue(reflect.ValueOf(&v), src); err != nil {
+		return err
+	}
+	*n = NewNull(v)
+	return nil
+}
+
+// Value implements driver.Valuer, returning nil unless n is valid.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	value, err := serializeFieldValue(reflect.ValueOf(&n.V).Elem(), serializeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return driver.DefaultParameterConverter.ConvertValue(value)
+}
+
+// MarshalJSON encodes V if valid, otherwise null.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+// UnmarshalJSON decodes null as explicitly NULL and other JSON into V.
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = NullOf[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*n = NewNull(v)
+	return nil
+}
+
+// nullTarget is implemented by *Null[T] so deserialization can decode into V directly.
+type nullTarget interface {
+	valueTarget() reflect.Value
+	setValid(valid bool)
+}
+
+func (n *Null[T]) valueTarget() reflect.Value {
+	return reflect.ValueOf(&n.V)
+}
+
+func (n *Null[T]) setValid(valid bool) {
+	if !valid {
+		var zero T
+		n.V = zero
+	}
+	n.Valid = valid
+	n.null = !valid
+}
+
+// nullSource is implemented by Null[T] so serialization can encode V with field tags and DB settings.
+type nullSource interface {
+	nullState() (value reflect.Value, valid, unset bool)
+}
+
+func (n Null[T]) nullState() (value reflect.Value, valid, unset bool) {
+	return reflect.ValueOf(&n.V).Elem(), n.Valid, n.IsUnset()
+}
+
+// asNullSource returns the Null state of a field value. ok is false for other types.
+func asNullSource(v reflect.Value) (value reflect.Value, valid, unset, ok bool) {
+	if !v.IsValid() || v.Kind() != reflect.Struct || !v.CanInterface() {
+		return reflect.Value{}, false, false, false
+	}
+	source, isNull := v.Interface().(nullSource)
+	if !isNull {
+		return reflect.Value{}, false, false, false
+	}
+	value, valid, unset = source.nullState()
+	return value, valid, unset, true
+}
+
+// comparableNull returns a value for partial update comparison that ignores the difference
+// between unset and NULL, so only changes between NULL and values (or between values) are detected.
+// ok is false for non-Null fields.
+func comparableNull(v reflect.Value) (comparable reflect.Value, ok bool) {
+	value, valid, _, isNull := asNullSource(v)
+	if !isNull {
+		return reflect.Value{}, false
+	}
+	if !valid {
+		return reflect.ValueOf([]any{false}), true
+	}
+	if decimal, ok := comparableDecimal(value); ok {
+		value = decimal
+	}
+	return reflect.ValueOf([]any{true, value.Interface()}), true
+}
@@ -0,0 +1,254 @@
+package typedb
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// NullTestUser is a test model with Null[T] fields
+type NullTestUser struct {
+	Model
+	SeenAt  Null[time.Time] `db:"seen_at" timeFormat:"unix"`
+	Balance Null[Decimal]   `db:"balance"`
+	Bio     Null[string]    `db:"bio"`
+	Logins  Null[int]       `db:"logins"`
+	Active  Null[bool]      `db:"active"`
+	Score   Null[float64]   `db:"score"`
+	Name    string          `db:"name"`
+	ID      int64           `db:"id" load:"primary"`
+}
+
+func (u *NullTestUser) TableName() string {
+	return "users"
+}
+
+func (u *NullTestUser) QueryByID() string {
+	return "SELECT id, name, bio, logins, active, score, seen_at, balance FROM users WHERE id = ?"
+}
+
+func TestNull_States(t *testing.T) {
+	var unset Null[int]
+	if !unset.IsUnset() || unset.IsNull() || unset.Valid {
+		t.Errorf("Expected zero value to be unset: %+v", unset)
+	}
+
+	null := NullOf[int]()
+	if null.IsUnset() || !null.IsNull() || null.Valid {
+		t.Errorf("Expected NullOf to be NULL: %+v", null)
+	}
+
+	zero := NewNull(0)
+	if zero.IsUnset() || zero.IsNull() || !zero.Valid {
+		t.Errorf("Expected NewNull(0) to be valid: %+v", zero)
+	}
+	if v, ok := zero.Get(); v != 0 || !ok {
+		t.Errorf("Get() = %v, %v", v, ok)
+	}
+	if null.ValueOr(5) != 5 || NewNull(3).ValueOr(5) != 3 {
+		t.Error("Unexpected ValueOr results")
+	}
+}
+
+func TestNull_ScanAndValue(t *testing.T) {
+	var n Null[int64]
+	if err := n.Scan("42"); err != nil || !n.Valid || n.V != 42 {
+		t.Errorf("Scan(\"42\") = %+v, %v", n, err)
+	}
+	if err := n.Scan(nil); err != nil || !n.IsNull() || n.V != 0 {
+		t.Errorf("Scan(nil) = %+v, %v", n, err)
+	}
+
+	value, err := NewNull(int8(7)).Value()
+	if err != nil || value != int64(7) {
+		t.Errorf("Expected driver-compatible int64, got %#v, %v", value, err)
+	}
+	value, err = NullOf[string]().Value()
+	if err != nil || value != nil {
+		t.Errorf("Expected nil for NULL, got %#v, %v", value, err)
+	}
+	value, err = NewNull(MustParseDecimal("1.50")).Value()
+	if err != nil || value != "1.50" {
+		t.Errorf("Expected decimal text, got %#v, %v", value, err)
+	}
+}
+
+func TestNull_JSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		A Null[int]
+		B Null[string]
+	}{A: NewNull(0), B: NullOf[string]()})
+	if err != nil || string(data) != `{"A":0,"B":null}` {
+		t.Errorf("Unexpected JSON %s, %v", data, err)
+	}
+
+	var decoded struct {
+		A Null[int]
+		B Null[string]
+	}
+	if err := json.Unmarshal([]byte(`{"A":5,"B":null}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !decoded.A.Valid || decoded.A.V != 5 || !decoded.B.IsNull() {
+		t.Errorf("Unexpected decoded values %+v", decoded)
+	}
+}
+
+func TestNull_Deserialize(t *testing.T) {
+	user, err := deserializeForType[*NullTestUser](map[string]any{
+		"bio":     nil,
+		"logins":  int64(0),
+		"active":  "true",
+		"score":   2.5,
+		"seen_at": int64(1700000000),
+		"balance": "10.25",
+	})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if !user.Bio.IsNull() {
+		t.Errorf("Expected NULL bio, got %+v", user.Bio)
+	}
+	if !user.Logins.Valid || user.Logins.V != 0 || !user.Active.V || user.Score.V != 2.5 {
+		t.Errorf("Unexpected values %+v %+v %+v", user.Logins, user.Active, user.Score)
+	}
+	if !user.SeenAt.Valid || !user.SeenAt.V.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expected timeFormat to apply inside Null, got %+v", user.SeenAt)
+	}
+	if !user.Balance.Valid || user.Balance.V.String() != "10.25" {
+		t.Errorf("Unexpected balance %+v", user.Balance)
+	}
+
+	// NULL over an existing value resets it
+	user.Logins = NewNull(3)
+	if err := deserialize(map[string]any{"logins": nil}, user); err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if !user.Logins.IsNull() || user.Logins.V != 0 {
+		t.Errorf("Expected NULL logins, got %+v", user.Logins)
+	}
+}
+
+func TestNull_InsertSemantics(t *testing.T) {
+	seenAt := time.Unix(1700000000, 0)
+	user := &NullTestUser{
+		Name:   "Alice",
+		Bio:    NullOf[string](),
+		Logins: NewNull(0),
+		Active: NewNull(false),
+		SeenAt: NewNull(seenAt),
+	}
+
+	columns, values, _, err := serializeModelFields(user, "ID")
+	if err != nil {
+		t.Fatalf("serializeModelFields failed: %v", err)
+	}
+	got := make(map[string]any)
+	for i, column := range columns {
+		got[column] = values[i]
+	}
+
+	want := map[string]any{"name": "Alice", "bio": nil, "logins": 0, "active": false, "seen_at": int64(1700000000)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestNull_UpdateSemantics(t *testing.T) {
+	user := &NullTestUser{ID: 1, Bio: NullOf[string](), Logins: NewNull(0)}
+	columns, values, _, _, err := serializeModelFieldsForUpdate(user, "ID", "sqlite3", nil)
+	if err != nil {
+		t.Fatalf("serializeModelFieldsForUpdate failed: %v", err)
+	}
+	if !reflect.DeepEqual(columns, []string{"bio", "logins"}) || !reflect.DeepEqual(values, []any{nil, 0}) {
+		t.Errorf("Expected NULL bio and zero logins only, got %v %v", columns, values)
+	}
+}
+
+func TestNull_PartialUpdate(t *testing.T) {
+	withModelOptions(t, reflect.TypeOf(NullTestUser{}), ModelOptions{PartialUpdate: true})
+
+	user, err := deserializeForType[*NullTestUser](map[string]any{"id": int64(1), "name": "Alice", "bio": nil, "logins": int64(2), "balance": "1.50"})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+
+	changed, err := getChangedFields(user, "ID")
+	if err != nil {
+		t.Fatalf("getChangedFields failed: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("Expected no changes after load, got %v", changed)
+	}
+
+	user.Bio = NewNull("")
+	user.Logins = NullOf[int]()
+	user.Balance = NewNull(MustParseDecimal("1.5"))
+	changed, err = getChangedFields(user, "ID")
+	if err != nil {
+		t.Fatalf("getChangedFields failed: %v", err)
+	}
+	if !reflect.DeepEqual(changed, map[string]bool{"bio": true, "logins": true}) {
+		t.Errorf("Expected bio and logins to change, got %v", changed)
+	}
+
+	columns, values, _, _, err := serializeModelFieldsForUpdate(user, "ID", "sqlite3", changed)
+	if err != nil {
+		t.Fatalf("serializeModelFieldsForUpdate failed: %v", err)
+	}
+	got := make(map[string]any)
+	for i, column := range columns {
+		got[column] = values[i]
+	}
+	if !reflect.DeepEqual(got, map[string]any{"bio": "", "logins": nil}) {
+		t.Errorf("Expected empty bio and NULL logins, got %v", got)
+	}
+}
+
+func TestNull_SQLiteRoundTrip(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, bio TEXT DEFAULT 'unset', logins INTEGER DEFAULT 9, active BOOLEAN, score REAL, seen_at INTEGER, balance TEXT)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	user := &NullTestUser{Name: "Alice", Logins: NewNull(0), Active: NullOf[bool]()}
+	if err := Insert(ctx, db, user); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	loaded := &NullTestUser{ID: user.ID}
+	if err := Load(ctx, db, loaded); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !loaded.Bio.Valid || loaded.Bio.V != "unset" {
+		t.Errorf("Expected unset bio to use the column default, got %+v", loaded.Bio)
+	}
+	if !loaded.Logins.Valid || loaded.Logins.V != 0 {
+		t.Errorf("Expected explicit zero logins, got %+v", loaded.Logins)
+	}
+	if !loaded.Active.IsNull() || !loaded.Score.IsNull() {
+		t.Errorf("Expected NULL active and score, got %+v %+v", loaded.Active, loaded.Score)
+	}
+
+	loaded.Bio = NullOf[string]()
+	loaded.Score = NewNull(0.0)
+	if err := Update(ctx, db, loaded); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	reloaded := &NullTestUser{ID: user.ID}
+	if err := Load(ctx, db, reloaded); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !reloaded.Bio.IsNull() || !reloaded.Score.Valid || reloaded.Score.V != 0 || reloaded.Logins.V != 0 {
+		t.Errorf("Unexpected state after update: %+v", reloaded)
+	}
+}
@@ -0,0 +1,596 @@
+package typedb
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PageOptions configures keyset (cursor) pagination for QueryPage.
+type PageOptions struct {
+	// Cursor is an opaque token returned as NextCursor or PrevCursor by a previous call.
+	// Leave empty to fetch the first page.
+	Cursor string
+
+	// KeyFields lists the Go field names that define the page ordering (e.g., []string{"CreatedAt", "ID"}).
+	// The combination must be unique across rows. Defaults to the field with the load:"primary" tag.
+	KeyFields []string
+
+	// Size is the maximum number of items per page. Must be greater than zero.
+	Size int
+
+	// Descending orders the key fields in descending order.
+	Descending bool
+}
+
+// Page holds one page of results returned by QueryPage.
+type Page[T ModelInterface] struct {
+	// NextCursor fetches the page after this one. Empty when HasNext is false.
+	NextCursor string
+
+	// PrevCursor fetches the page before this one. Empty when HasPrev is false.
+	PrevCursor string
+
+	// Items holds the models for this page in key order.
+	Items []T
+
+	HasNext bool
+	HasPrev bool
+}
+
+var (
+	cursorKey      []byte
+	cursorKeyMutex sync.RWMutex
+)
+
+func init() {
+	cursorKey = make([]byte, 32)
+	if _, err := rand.Read(cursorKey); err != nil {
+		panic(fmt.Errorf("typedb: failed to generate cursor signing key: %w", err))
+	}
+}
+
+// SetCursorKey sets the secret used to sign pagination cursors.
+// By default a random key is generated at startup, so cursors are only valid within one process.
+// Set a shared key when cursors must survive restarts or be accepted by multiple instances.
+func SetCursorKey(key []byte) {
+	if len(key) == 0 {
+		return
+	}
+	cursorKeyMutex.Lock()
+	defer cursorKeyMutex.Unlock()
+	cursorKey = append([]byte(nil), key...)
+}
+
+// QueryPage executes a query using keyset (cursor) pagination and returns one page of results.
+// The base query is wrapped as a subquery, so it may contain WHERE, JOIN and GROUP BY clauses
+// but must not contain ORDER BY or LIMIT. QueryPage appends the key comparison, ORDER BY and
+// row limit appropriate for the executor's driver (LIMIT for PostgreSQL, MySQL and SQLite,
+// TOP for SQL Server, OFFSET ... FETCH for Oracle).
+//
+// Cursor tokens are signed with the key set by SetCursorKey and encode the key values with
+// their types. A modified or foreign token returns an error wrapping ErrInvalidCursor.
+//
+// Example:
+//
+//	page, err := typedb.QueryPage[*User](ctx, db,
+//		"SELECT id, name, email FROM users WHERE active = $1",
+//		typedb.PageOptions{Size: 50, Cursor: req.Cursor}, true)
+//	// page.Items, page.NextCursor, page.PrevCursor
+func QueryPage[T ModelInterface](ctx context.Context, exec Executor, query string, opts PageOptions, args ...any) (*Page[T], error) {
+	if opts.Size <= 0 {
+		return nil, fmt.Errorf("typedb: QueryPage requires a page size greater than zero")
+	}
+
+	keys, err := resolvePageKeys[T](opts.KeyFields)
+	if err != nil {
+		return nil, fmt.Errorf("typedb: QueryPage: %w", err)
+	}
+
+	backward := false
+	var cursorValues []any
+	if opts.Cursor != "" {
+		var direction string
+		direction, cursorValues, err = decodeCursor(opts.Cursor, keys)
+		if err != nil {
+			return nil, err
+		}
+		backward = direction == cursorBefore
+	}
+
+	driverName := getDriverName(exec)
+	pageQuery, pageArgs, maskIndices := buildKeysetQuery(driverName, query, args, keys, cursorValues, opts.Descending, backward, opts.Size+1)
+	if len(maskIndices) > 0 {
+		ctx = WithMaskIndices(ctx, maskIndices)
+	}
+
+	items, err := QueryAll[T](ctx, exec, pageQuery, pageArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(items) > opts.Size
+	if hasMore {
+		items = items[:opts.Size]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	page := &Page[T]{Items: items}
+	if backward {
+		page.HasPrev = hasMore
+		page.HasNext = len(items) > 0
+	} else {
+		page.HasNext = hasMore
+		page.HasPrev = opts.Cursor != "" && len(items) > 0
+	}
+
+	if page.HasNext {
+		page.NextCursor, err = encodeCursor(cursorAfter, items[len(items)-1], keys)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if page.HasPrev {
+		page.PrevCursor, err = encodeCursor(cursorBefore, items[0], keys)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// pageKey describes one key field used for keyset pagination.
+type pageKey struct {
+	fieldName string
+	column    string
+	fieldType reflect.Type
+	nolog     bool
+}
+
+// resolvePageKeys resolves the key field names (or the primary key) to their columns and types.
+func resolvePageKeys[T ModelInterface](keyFields []string) ([]pageKey, error) {
+	var model T
+	modelType := reflect.TypeOf(model)
+	if modelType == nil || modelType.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("T must be a pointer type (e.g., *User)")
+	}
+	structType := modelType.Elem()
+
+	if len(keyFields) == 0 {
+		primaryField, found := findFieldByTagRecursive(structType, "load", "primary")
+		if !found {
+			return nil, fmt.Errorf("no key fields given and no field with load:\"primary\" tag found")
+		}
+		keyFields = []string{primaryField.Name}
+	}
+
+	keys := make([]pageKey, 0, len(keyFields))
+	for _, name := range keyFields {
+		field, found := findFieldByNameRecursive(structType, name)
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, name)
+		}
+		column := field.Tag.Get("db")
+		if column == "" || column == "-" {
+			return nil, fmt.Errorf("key field %s must have a db tag", name)
+		}
+		if strings.Contains(column, ".") {
+			parts := strings.Split(column, ".")
+			column = parts[len(parts)-1]
+		}
+		if cursorTypeCode(field.Type) == "" {
+			return nil, fmt.Errorf("key field %s has unsupported type %v", name, field.Type)
+		}
+		keys = append(keys, pageKey{
+			fieldName: name,
+			column:    column,
+			fieldType: field.Type,
+			nolog:     field.Tag.Get("nolog") == "true",
+		})
+	}
+
+	return keys, nil
+}
+
+// buildKeysetQuery wraps the base query with the keyset comparison, ORDER BY and row limit.
+// Returns the query, the combined arguments and the indices of key arguments to mask in logs.
+func buildKeysetQuery(driverName, baseQuery string, baseArgs []any, keys []pageKey, cursorValues []any, descending, backward bool, limit int) (query string, args []any, maskIndices []int) {
+	// Scanning backward reverses both the comparison and the sort order
+	reverse := descending != backward
+	comparison := ">"
+	direction := "ASC"
+	if reverse {
+		comparison = "<"
+		direction = "DESC"
+	}
+
+	columns := make([]string, len(keys))
+	orderBy := make([]string, len(keys))
+	for i, key := range keys {
+		columns[i] = quoteIdentifier(driverName, key.column)
+		orderBy[i] = columns[i] + " " + direction
+	}
+
+	args = make([]any, len(baseArgs), len(baseArgs)+len(cursorValues))
+	copy(args, baseArgs)
+
+	where := ""
+	if len(cursorValues) > 0 {
+		placeholders := make([]string, len(cursorValues))
+		for i, value := range cursorValues {
+			if keys[i].nolog {
+				maskIndices = append(maskIndices, len(args))
+			}
+			args = append(args, value)
+			placeholders[i] = generatePlaceholder(driverName, len(args))
+		}
+		where = " WHERE " + buildKeysetPredicate(driverName, columns, placeholders, comparison)
+	}
+
+	return buildLimitedQuery(driverName, "*", baseQuery, where, strings.Join(orderBy, ", "), limit, 0), args, maskIndices
+}
+
+// buildKeysetPredicate builds the "after key" comparison.
+// Uses row-value comparison where the database supports it, and the expanded
+// (a > x) OR (a = x AND b > y) form for SQL Server and Oracle.
+func buildKeysetPredicate(driverName string, columns, placeholders []string, comparison string) string {
+	if len(columns) == 1 {
+		return fmt.Sprintf("%s %s %s", columns[0], comparison, placeholders[0])
+	}
+
+	switch strings.ToLower(driverName) {
+	case "sqlserver", "mssql", "oracle":
+		terms := make([]string, len(columns))
+		for i := range columns {
+			parts := make([]string, 0, i+1)
+			for j := 0; j < i; j++ {
+				parts = append(parts, fmt.Sprintf("%s = %s", columns[j], placeholders[j]))
+			}
+			parts = append(parts, fmt.Sprintf("%s %s %s", columns[i], comparison, placeholders[i]))
+			terms[i] = "(" + strings.Join(parts, " AND ") + ")"
+		}
+		return "(" + strings.Join(terms, " OR ") + ")"
+	default:
+		return fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), comparison, strings.Join(placeholders, ", "))
+	}
+}
+
+// buildLimitedQuery wraps baseQuery as a subquery, selects selectList from it and applies the
+// WHERE clause, ORDER BY and driver-specific row limiting. where must be empty or start with " WHERE ".
+func buildLimitedQuery(driverName, selectList, baseQuery, where, orderBy string, limit, offset int) string {
+	baseQuery = strings.TrimRight(strings.TrimSpace(baseQuery), ";")
+
+	switch strings.ToLower(driverName) {
+	case "sqlserver", "mssql":
+		if offset == 0 {
+			query := fmt.Sprintf("SELECT TOP (%d) %s FROM (%s) typedb_page%s", limit, selectList, baseQuery, where)
+			if orderBy != "" {
+				query += " ORDER BY " + orderBy
+			}
+			return query
+		}
+		if orderBy == "" {
+			// OFFSET ... FETCH requires an ORDER BY clause
+			orderBy = "(SELECT NULL)"
+		}
+		return fmt.Sprintf("SELECT %s FROM (%s) typedb_page%s ORDER BY %s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", selectList, baseQuery, where, orderBy, offset, limit)
+	case "oracle":
+		query := fmt.Sprintf("SELECT %s FROM (%s) typedb_page%s", selectList, baseQuery, where)
+		if orderBy != "" {
+			query += " ORDER BY " + orderBy
+		}
+		return query + fmt.Sprintf(" OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", offset, limit)
+	default:
+		query := fmt.Sprintf("SELECT %s FROM (%s) typedb_page%s", selectList, baseQuery, where)
+		if orderBy != "" {
+			query += " ORDER BY " + orderBy
+		}
+		query += fmt.Sprintf(" LIMIT %d", limit)
+		if offset > 0 {
+			query += fmt.Sprintf(" OFFSET %d", offset)
+		}
+		return query
+	}
+}
+
+// Cursor directions
+const (
+	cursorAfter  = "a"
+	cursorBefore = "b"
+)
+
+// cursorPayload is the signed content of a pagination cursor.
+type cursorPayload struct {
+	Direction string        `json:"d"`
+	Columns   []string      `json:"c"`
+	Values    []cursorValue `json:"v"`
+}
+
+// cursorValue is a key value tagged with its type code so it decodes to the original Go type.
+type cursorValue struct {
+	Type  string `json:"t"`
+	Value string `json:"v"`
+}
+
+// cursorTypeCode returns the cursor type code for a key field type, or "" if unsupported.
+func cursorTypeCode(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Time{}) {
+		return "t"
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "i"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "u"
+	case reflect.Float32, reflect.Float64:
+		return "f"
+	case reflect.String:
+		return "s"
+	case reflect.Bool:
+		return "b"
+	default:
+		return ""
+	}
+}
+
+// encodeCursor builds a signed cursor from the key values of model.
+func encodeCursor(direction string, model any, keys []pageKey) (string, error) {
+	payload := cursorPayload{
+		Direction: direction,
+		Columns:   make([]string, len(keys)),
+		Values:    make([]cursorValue, len(keys)),
+	}
+
+	for i, key := range keys {
+		fieldValue, err := getFieldValue(model, key.fieldName)
+		if err != nil {
+			return "", fmt.Errorf("typedb: failed to build cursor: %w", err)
+		}
+		payload.Columns[i] = key.column
+
+		code := cursorTypeCode(key.fieldType)
+		var text string
+		switch code {
+		case "t":
+			text = fieldValue.Interface().(time.Time).Format(time.RFC3339Nano)
+		case "i":
+			text = strconv.FormatInt(fieldValue.Int(), 10)
+		case "u":
+			text = strconv.FormatUint(fieldValue.Uint(), 10)
+		case "f":
+			text = strconv.FormatFloat(fieldValue.Float(), 'g', -1, 64)
+		case "s":
+			text = fieldValue.String()
+		case "b":
+			text = strconv.FormatBool(fieldValue.Bool())
+		}
+		payload.Values[i] = cursorValue{Type: code, Value: text}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("typedb: failed to build cursor: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signCursor(encoded)), nil
+}
+
+// decodeCursor verifies a cursor and returns its direction and typed key values.
+func decodeCursor(token string, keys []pageKey) (direction string, values []any, err error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", nil, fmt.Errorf("%w: malformed token", ErrInvalidCursor)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, signCursor(encoded)) {
+		return "", nil, fmt.Errorf("%w: signature mismatch", ErrInvalidCursor)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	if payload.Direction != cursorAfter && payload.Direction != cursorBefore {
+		return "", nil, fmt.Errorf("%w: unknown direction", ErrInvalidCursor)
+	}
+	if len(payload.Values) != len(keys) || len(payload.Columns) != len(keys) {
+		return "", nil, fmt.Errorf("%w: cursor has %d key(s), expected %d", ErrInvalidCursor, len(payload.Values), len(keys))
+	}
+
+	values = make([]any, len(keys))
+	for i, key := range keys {
+		if payload.Columns[i] != key.column {
+			return "", nil, fmt.Errorf("%w: cursor key %q does not match %q", ErrInvalidCursor, payload.Columns[i], key.column)
+		}
+		value, err := decodeCursorValue(payload.Values[i], key.fieldType)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: key %s: %v", ErrInvalidCursor, key.column, err)
+		}
+		values[i] = value
+	}
+
+	return payload.Direction, values, nil
+}
+
+// decodeCursorValue converts an encoded cursor value back into a value of fieldType.
+func decodeCursorValue(cv cursorValue, fieldType reflect.Type) (any, error) {
+	if code := cursorTypeCode(fieldType); cv.Type != code {
+		return nil, fmt.Errorf("type %q does not match field type %v", cv.Type, fieldType)
+	}
+
+	target := reflect.New(fieldType).Elem()
+	switch cv.Type {
+	case "t":
+		t, err := time.Parse(time.RFC3339Nano, cv.Value)
+		if err != nil {
+			return nil, err
+		}
+		target.Set(reflect.ValueOf(t))
+	case "i":
+		n, err := strconv.ParseInt(cv.Value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		target.SetInt(n)
+	case "u":
+		n, err := strconv.ParseUint(cv.Value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		target.SetUint(n)
+	case "f":
+		f, err := strconv.ParseFloat(cv.Value, 64)
+		if err != nil {
+			return nil, err
+		}
+		target.SetFloat(f)
+	case "s":
+		target.SetString(cv.Value)
+	case "b":
+		b, err := strconv.ParseBool(cv.Value)
+		if err != nil {
+			return nil, err
+		}
+		target.SetBool(b)
+	}
+
+	return target.Interface(), nil
+}
+
+// signCursor returns the HMAC-SHA256 signature of an encoded cursor payload.
+func signCursor(encoded string) []byte {
+	cursorKeyMutex.RLock()
+	mac := hmac.New(sha256.New, cursorKey)
+	cursorKeyMutex.RUnlock()
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// PagedOptions configures offset pagination for QueryPaged.
+type PagedOptions struct {
+	// OrderBy is the ORDER BY expression applied to the wrapped query (e.g., "name ASC, id ASC").
+	// It references the columns returned by the base query. Strongly recommended, as
+	// pages are not stable without a deterministic order.
+	OrderBy string
+
+	// Page is the 1-based page number. Values below 1 are treated as 1.
+	Page int
+
+	// PageSize is the number of items per page. Must be greater than zero.
+	PageSize int
+
+	// SingleQuery fetches the total with COUNT(*) OVER() in the same round trip
+	// instead of running a separate COUNT(*) query.
+	SingleQuery bool
+}
+
+// PagedResult holds one page of results and the total row count returned by QueryPaged.
+type PagedResult[T ModelInterface] struct {
+	Items     []T
+	Total     int64
+	Page      int
+	PageSize  int
+	PageCount int
+}
+
+// totalCountColumn is the column alias used for COUNT(*) OVER() in single-query mode.
+const totalCountColumn = "typedb_total_count"
+
+// QueryPaged executes a query using offset pagination and returns one page of results with
+// the total number of rows. The base query is wrapped as a subquery, so it must not contain
+// ORDER BY or LIMIT; use PagedOptions.OrderBy instead. The same args are used for the page
+// and count queries, and the context (including WithNoLogging) applies to both.
+//
+// By default two queries are executed: the page query with driver-appropriate LIMIT/OFFSET
+// (or OFFSET ... FETCH) and SELECT COUNT(*) over the base query. With SingleQuery the total is
+// read from COUNT(*) OVER() in the page query; a separate count is only run when the
+// requested page is past the end and returns no rows.
+//
+// Example:
+//
+//	result, err := typedb.QueryPaged[*User](ctx, db,
+//		"SELECT id, name, email FROM users WHERE active = $1",
+//		typedb.PagedOptions{Page: 2, PageSize: 25, OrderBy: "name, id"}, true)
+//	// result.Items, result.Total, result.PageCount
+func QueryPaged[T ModelInterface](ctx context.Context, exec Executor, query string, opts PagedOptions, args ...any) (*PagedResult[T], error) {
+	if opts.PageSize <= 0 {
+		return nil, fmt.Errorf("typedb: QueryPaged requires a page size greater than zero")
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+
+	driverName := getDriverName(exec)
+	offset := (opts.Page - 1) * opts.PageSize
+	result := &PagedResult[T]{Page: opts.Page, PageSize: opts.PageSize}
+
+	totalKnown := false
+	if opts.SingleQuery {
+		selectList := "typedb_page.*, COUNT(*) OVER() AS " + totalCountColumn
+		pageQuery := buildLimitedQuery(driverName, selectList, query, "", opts.OrderBy, opts.PageSize, offset)
+
+		rows, err := exec.QueryAll(withDuplicateColumnKeys(ctx), pageQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		deserializeOpts := newDeserializeOptions(ctx, exec)
+		result.Items = make([]T, 0, len(rows))
+		for _, row := range rows {
+			if !totalKnown {
+				total, err := deserializeInt64(row[totalCountColumn])
+				if err != nil {
+					return nil, fmt.Errorf("typedb: QueryPaged failed to read total count: %w", err)
+				}
+				result.Total = total
+				totalKnown = true
+			}
+			delete(row, totalCountColumn)
+
+			model, err := deserializeForTypeWithOptions[T](row, deserializeOpts)
+			if err != nil {
+				return nil, err
+			}
+			result.Items = append(result.Items, model)
+		}
+	} else {
+		pageQuery := buildLimitedQuery(driverName, "*", query, "", opts.OrderBy, opts.PageSize, offset)
+		items, err := QueryAll[T](ctx, exec, pageQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = items
+	}
+
+	if !totalKnown {
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (%s) typedb_count", strings.TrimRight(strings.TrimSpace(query), ";"))
+		if err := exec.GetInto(ctx, countQuery, args, &result.Total); err != nil {
+			return nil, fmt.Errorf("typedb: QueryPaged count failed: %w", err)
+		}
+	}
+
+	result.PageCount = int((result.Total + int64(opts.PageSize) - 1) / int64(opts.PageSize))
+	return result, nil
+}
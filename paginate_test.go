@@ -0,0 +1,323 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// PageTestItem is a test model for pagination tests
+type PageTestItem struct {
+	Model
+	Name  string `db:"name"`
+	ID    int64  `db:"id" load:"primary"`
+	Score int    `db:"score"`
+}
+
+func (p *PageTestItem) QueryByID() string {
+	return "SELECT id, name, score FROM items WHERE id = ?"
+}
+
+// openPageTestDB opens an in-memory SQLite database with count rows in the items table.
+func openPageTestDB(t *testing.T, count int) *DB {
+	t.Helper()
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Logf("Warning: failed to close database: %v", err)
+		}
+	})
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT, score INTEGER)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	for i := 1; i <= count; i++ {
+		if _, err := db.Exec(ctx, "INSERT INTO items (id, name, score) VALUES (?, ?, ?)", i, fmt.Sprintf("item%d", i), i%3); err != nil {
+			t.Fatalf("Failed to insert row: %v", err)
+		}
+	}
+	return db
+}
+
+func pageIDs(items []*PageTestItem) []int64 {
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestQueryPage_SQLite_ForwardAndBackward(t *testing.T) {
+	db := openPageTestDB(t, 7)
+	ctx := context.Background()
+	query := "SELECT id, name, score FROM items"
+
+	page1, err := QueryPage[*PageTestItem](ctx, db, query, PageOptions{Size: 3})
+	if err != nil {
+		t.Fatalf("QueryPage failed: %v", err)
+	}
+	if got := fmt.Sprint(pageIDs(page1.Items)); got != "[1 2 3]" {
+		t.Fatalf("Expected page 1 [1 2 3], got %s", got)
+	}
+	if !page1.HasNext || page1.HasPrev || page1.PrevCursor != "" {
+		t.Fatalf("Unexpected page 1 flags: %+v", page1)
+	}
+
+	page2, err := QueryPage[*PageTestItem](ctx, db, query, PageOptions{Size: 3, Cursor: page1.NextCursor})
+	if err != nil {
+		t.Fatalf("QueryPage failed: %v", err)
+	}
+	if got := fmt.Sprint(pageIDs(page2.Items)); got != "[4 5 6]" {
+		t.Fatalf("Expected page 2 [4 5 6], got %s", got)
+	}
+	if !page2.HasNext || !page2.HasPrev {
+		t.Fatalf("Unexpected page 2 flags: %+v", page2)
+	}
+
+	page3, err := QueryPage[*PageTestItem](ctx, db, query, PageOptions{Size: 3, Cursor: page2.NextCursor})
+	if err != nil {
+		t.Fatalf("QueryPage failed: %v", err)
+	}
+	if got := fmt.Sprint(pageIDs(page3.Items)); got != "[7]" {
+		t.Fatalf("Expected page 3 [7], got %s", got)
+	}
+	if page3.HasNext || page3.NextCursor != "" {
+		t.Fatalf("Expected last page, got %+v", page3)
+	}
+
+	back, err := QueryPage[*PageTestItem](ctx, db, query, PageOptions{Size: 3, Cursor: page3.PrevCursor})
+	if err != nil {
+		t.Fatalf("QueryPage failed: %v", err)
+	}
+	if got := fmt.Sprint(pageIDs(back.Items)); got != "[4 5 6]" {
+		t.Fatalf("Expected previous page [4 5 6], got %s", got)
+	}
+
+	first, err := QueryPage[*PageTestItem](ctx, db, query, PageOptions{Size: 3, Cursor: back.PrevCursor})
+	if err != nil {
+		t.Fatalf("QueryPage failed: %v", err)
+	}
+	if got := fmt.Sprint(pageIDs(first.Items)); got != "[1 2 3]" {
+		t.Fatalf("Expected first page [1 2 3], got %s", got)
+	}
+	if first.HasPrev {
+		t.Errorf("Expected no previous page before first page")
+	}
+}
+
+func TestQueryPage_SQLite_CompositeKeysDescending(t *testing.T) {
+	db := openPageTestDB(t, 6)
+	ctx := context.Background()
+	query := "SELECT id, name, score FROM items WHERE id > ?"
+	opts := PageOptions{Size: 2, KeyFields: []string{"Score", "ID"}, Descending: true}
+
+	var seen []int64
+	for {
+		page, err := QueryPage[*PageTestItem](ctx, db, query, opts, 0)
+		if err != nil {
+			t.Fatalf("QueryPage failed: %v", err)
+		}
+		seen = append(seen, pageIDs(page.Items)...)
+		if !page.HasNext {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+
+	// score = id % 3, ordered by (score DESC, id DESC)
+	if got := fmt.Sprint(seen); got != "[5 2 4 1 6 3]" {
+		t.Fatalf("Expected [5 2 4 1 6 3], got %s", got)
+	}
+}
+
+func TestQueryPage_InvalidCursor(t *testing.T) {
+	db := openPageTestDB(t, 3)
+	ctx := context.Background()
+	query := "SELECT id, name, score FROM items"
+
+	page, err := QueryPage[*PageTestItem](ctx, db, query, PageOptions{Size: 1})
+	if err != nil {
+		t.Fatalf("QueryPage failed: %v", err)
+	}
+
+	encoded, sig, _ := strings.Cut(page.NextCursor, ".")
+	tampered := encoded[:len(encoded)-1] + "A" + "." + sig
+	tests := map[string]PageOptions{
+		"tampered":        {Size: 1, Cursor: tampered},
+		"malformed":       {Size: 1, Cursor: "not-a-cursor"},
+		"other key set":   {Size: 1, Cursor: page.NextCursor, KeyFields: []string{"Score", "ID"}},
+		"other key field": {Size: 1, Cursor: page.NextCursor, KeyFields: []string{"Name"}},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := QueryPage[*PageTestItem](ctx, db, query, opts)
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("Expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+}
+
+func TestQueryPage_OptionErrors(t *testing.T) {
+	ctx := context.Background()
+	mock := &MockExecutor{}
+
+	if _, err := QueryPage[*PageTestItem](ctx, mock, "SELECT 1", PageOptions{}); err == nil {
+		t.Error("Expected error for zero page size")
+	}
+	if _, err := QueryPage[*PageTestItem](ctx, mock, "SELECT 1", PageOptions{Size: 1, KeyFields: []string{"Missing"}}); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("Expected ErrFieldNotFound, got %v", err)
+	}
+	if _, err := QueryPage[*QueryTestUser](ctx, mock, "SELECT 1", PageOptions{Size: 1}); err == nil {
+		t.Error("Expected error for model without primary key")
+	}
+}
+
+func TestBuildKeysetQuery_Drivers(t *testing.T) {
+	keys := []pageKey{{fieldName: "Score", column: "score"}, {fieldName: "ID", column: "id", nolog: true}}
+	cursor := []any{2, int64(10)}
+
+	tests := []struct {
+		driver string
+		want   string
+	}{
+		{"postgres", `SELECT * FROM (SELECT id FROM items WHERE a = $1) typedb_page WHERE ("score", "id") > ($2, $3) ORDER BY "score" ASC, "id" ASC LIMIT 11`},
+		{"mysql", "SELECT * FROM (SELECT id FROM items WHERE a = $1) typedb_page WHERE (`score`, `id`) > (?, ?) ORDER BY `score` ASC, `id` ASC LIMIT 11"},
+		{"sqlserver", `SELECT TOP (11) * FROM (SELECT id FROM items WHERE a = $1) typedb_page WHERE (([score] > @p2) OR ([score] = @p2 AND [id] > @p3)) ORDER BY [score] ASC, [id] ASC`},
+		{"oracle", `SELECT * FROM (SELECT id FROM items WHERE a = $1) typedb_page WHERE (("SCORE" > :2) OR ("SCORE" = :2 AND "ID" > :3)) ORDER BY "SCORE" ASC, "ID" ASC OFFSET 0 ROWS FETCH NEXT 11 ROWS ONLY`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			query, args, maskIndices := buildKeysetQuery(tt.driver, "SELECT id FROM items WHERE a = $1;", []any{"x"}, keys, cursor, false, false, 11)
+			if query != tt.want {
+				t.Errorf("Unexpected query:\n got: %s\nwant: %s", query, tt.want)
+			}
+			if len(args) != 3 || args[0] != "x" || args[2] != int64(10) {
+				t.Errorf("Unexpected args: %v", args)
+			}
+			if len(maskIndices) != 1 || maskIndices[0] != 2 {
+				t.Errorf("Expected mask index 2, got %v", maskIndices)
+			}
+		})
+	}
+
+	t.Run("backward reverses comparison and order", func(t *testing.T) {
+		query, _, _ := buildKeysetQuery("postgres", "SELECT id FROM items", nil, keys[1:], []any{int64(5)}, false, true, 3)
+		want := `SELECT * FROM (SELECT id FROM items) typedb_page WHERE "id" < $1 ORDER BY "id" DESC LIMIT 3`
+		if query != want {
+			t.Errorf("Unexpected query:\n got: %s\nwant: %s", query, want)
+		}
+	})
+}
+
+func TestQueryPaged_SQLite(t *testing.T) {
+	db := openPageTestDB(t, 7)
+	ctx := context.Background()
+	query := "SELECT id, name, score FROM items WHERE id <= ?"
+
+	for _, single := range []bool{false, true} {
+		t.Run(fmt.Sprintf("single query %v", single), func(t *testing.T) {
+			opts := PagedOptions{Page: 2, PageSize: 3, OrderBy: "id DESC", SingleQuery: single}
+			result, err := QueryPaged[*PageTestItem](ctx, db, query, opts, 7)
+			if err != nil {
+				t.Fatalf("QueryPaged failed: %v", err)
+			}
+			if got := fmt.Sprint(pageIDs(result.Items)); got != "[4 3 2]" {
+				t.Errorf("Expected [4 3 2], got %s", got)
+			}
+			if result.Total != 7 || result.PageCount != 3 || result.Page != 2 || result.PageSize != 3 {
+				t.Errorf("Unexpected result metadata: %+v", result)
+			}
+			if result.Items[0].Name != "item4" {
+				t.Errorf("Expected item4, got %q", result.Items[0].Name)
+			}
+
+			opts.Page = 5
+			result, err = QueryPaged[*PageTestItem](ctx, db, query, opts, 7)
+			if err != nil {
+				t.Fatalf("QueryPaged failed: %v", err)
+			}
+			if len(result.Items) != 0 || result.Total != 7 {
+				t.Errorf("Expected empty page with total 7, got %+v", result)
+			}
+		})
+	}
+
+	t.Run("page below 1 is treated as 1", func(t *testing.T) {
+		result, err := QueryPaged[*PageTestItem](ctx, db, query, PagedOptions{PageSize: 2, OrderBy: "id"}, 7)
+		if err != nil {
+			t.Fatalf("QueryPaged failed: %v", err)
+		}
+		if result.Page != 1 || fmt.Sprint(pageIDs(result.Items)) != "[1 2]" {
+			t.Errorf("Unexpected first page: %+v", result)
+		}
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		if _, err := QueryPaged[*PageTestItem](ctx, db, query, PagedOptions{Page: 1}, 7); err == nil {
+			t.Error("Expected error for zero page size")
+		}
+	})
+}
+
+func TestQueryPaged_WithNoLogging(t *testing.T) {
+	logger := &testLogger{}
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Logf("Warning: failed to close database: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT, score INTEGER)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	logger.debugs = nil
+	if _, err := QueryPaged[*PageTestItem](WithNoLogging(ctx), db, "SELECT id, name, score FROM items WHERE name = ?", PagedOptions{Page: 1, PageSize: 10}, "secret"); err != nil {
+		t.Fatalf("QueryPaged failed: %v", err)
+	}
+
+	if len(logger.debugs) < 2 {
+		t.Fatalf("Expected page and count queries to be logged, got %d entries", len(logger.debugs))
+	}
+	for _, entry := range logger.debugs {
+		for i := 0; i < len(entry.keyvals)-1; i += 2 {
+			if entry.keyvals[i] == "query" || entry.keyvals[i] == "args" {
+				t.Errorf("Expected no %v in logs with WithNoLogging, got entry %+v", entry.keyvals[i], entry)
+			}
+		}
+	}
+}
+
+func TestBuildLimitedQuery_Offset(t *testing.T) {
+	tests := []struct {
+		driver  string
+		orderBy string
+		want    string
+	}{
+		{"postgres", "id", `SELECT * FROM (SELECT id FROM items) typedb_page ORDER BY id LIMIT 10 OFFSET 20`},
+		{"sqlserver", "id", `SELECT * FROM (SELECT id FROM items) typedb_page ORDER BY id OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY`},
+		{"sqlserver", "", `SELECT * FROM (SELECT id FROM items) typedb_page ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY`},
+		{"oracle", "id", `SELECT * FROM (SELECT id FROM items) typedb_page ORDER BY id OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver+"/"+tt.orderBy, func(t *testing.T) {
+			if got := buildLimitedQuery(tt.driver, "*", "SELECT id FROM items", "", tt.orderBy, 10, 20); got != tt.want {
+				t.Errorf("Unexpected query:\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package typedb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Discriminator maps the values of a discriminator column to the concrete models of a polymorphic type.
+type Discriminator struct {
+	// Variants maps each discriminator value to a model type, given as a nil pointer
+	// (e.g., "click": (*ClickEvent)(nil)). Every model must implement the polymorphic type.
+	Variants map[string]ModelInterface
+
+	// Column is the result column holding the discriminator value (e.g., "kind").
+	Column string
+
+	// SkipUnknown skips rows whose discriminator value has no variant (or is NULL)
+	// instead of returning ErrUnknownDiscriminator.
+	SkipUnknown bool
+}
+
+// discriminatorEntry is a registered Discriminator with its variant types resolved.
+type discriminatorEntry struct {
+	variants    map[string]reflect.Type // Discriminator value -> struct type
+	column      string
+	skipUnknown bool
+}
+
+// discriminators maps a polymorphic type to its registered discriminatorEntry.
+var discriminators sync.Map // map[reflect.Type]*discriminatorEntry
+
+// RegisterDiscriminator registers the discriminator used by QueryPolymorphic[I].
+// I is usually an interface implemented by every variant model.
+// Panics if the column is empty, a variant is not a pointer to struct, or a variant does not implement I,
+// as RegisterDiscriminator is meant to be called from init() functions.
+// Registering a discriminator for a type that already has one replaces it.
+//
+// Example:
+//
+//	type Event interface{ Kind() string }
+//
+//	func init() {
+//	    typedb.RegisterDiscriminator[Event](typedb.Discriminator{
+//	        Column: "kind",
+//	        Variants: map[string]typedb.ModelInterface{
+//	            "click": (*ClickEvent)(nil),
+//	            "view":  (*ViewEvent)(nil),
+//	        },
+//	    })
+//	}
+func RegisterDiscriminator[I any](d Discriminator) {
+	iface := modelTypeOf[I]()
+	if d.Column == "" {
+		panic(fmt.Errorf("typedb: discriminator for %s requires a column", iface))
+	}
+
+	entry := &discriminatorEntry{
+		variants:    make(map[string]reflect.Type, len(d.Variants)),
+		column:      d.Column,
+		skipUnknown: d.SkipUnknown,
+	}
+	for value, variant := range d.Variants {
+		t := reflect.TypeOf(variant)
+		if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+			panic(fmt.Errorf("typedb: discriminator variant %q for %s must be a pointer to struct, got %v", value, iface, t))
+		}
+		if !t.Implements(iface) {
+			panic(fmt.Errorf("typedb: discriminator variant %q: %s does not implement %s", value, t, iface))
+		}
+		entry.variants[value] = t.Elem()
+	}
+
+	discriminators.Store(iface, entry)
+}
+
+// QueryPolymorphic executes a query and deserializes each row into the model registered for its
+// discriminator value with RegisterDiscriminator[I], returning the models as I.
+// Returns an empty slice if no rows are found.
+// Rows whose discriminator value has no registered variant return an error wrapping ErrUnknownDiscriminator,
+// or are skipped when the Discriminator has SkipUnknown set.
+// The discriminator column is only deserialized into variants that have a field tagged with it.
+//
+// Example:
+//
+//	events, err := typedb.QueryPolymorphic[Event](ctx, db, "SELECT id, kind, x, y, url FROM events")
+//	for _, event := range events {
+//	    switch e := event.(type) {
+//	    case *ClickEvent:
+//	        // ...
+//	    }
+//	}
+func QueryPolymorphic[I any](ctx context.Context, exec Executor, query string, args ...any) ([]I, error) {
+	iface := modelTypeOf[I]()
+	cached, ok := discriminators.Load(iface)
+	if !ok {
+		return nil, fmt.Errorf("typedb: no discriminator registered for %s", iface)
+	}
+	entry := cached.(*discriminatorEntry)
+
+	rows, err := exec.QueryAll(withDuplicateColumnKeys(ctx), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := newDeserializeOptions(ctx, exec)
+	result := make([]I, 0, len(rows))
+	for _, row := range rows {
+		column, value, valid, err := discriminatorValue(row, entry.column)
+		if err != nil {
+			return nil, err
+		}
+
+		variant, ok := entry.variants[value]
+		if !ok || !valid {
+			if entry.skipUnknown {
+				continue
+			}
+			if !valid {
+				return nil, fmt.Errorf("%w: %s is NULL for %s", ErrUnknownDiscriminator, entry.column, iface)
+			}
+			return nil, fmt.Errorf("%w: %s value %q for %s", ErrUnknownDiscriminator, entry.column, value, iface)
+		}
+
+		if _, found := findFieldByTagRecursive(variant, "db", column); !found {
+			// The discriminator is not part of this variant
+			delete(row, column)
+		}
+
+		model := reflect.New(variant).Interface().(ModelInterface)
+		if err := deserializeWithOptions(row, model, opts); err != nil {
+			return nil, err
+		}
+		result = append(result, model.(I))
+	}
+
+	return result, nil
+}
+
+// discriminatorValue returns the row map key of the discriminator column and its value as a string.
+// The column is matched case-insensitively. valid is false for NULL values.
+func discriminatorValue(row map[string]any, column string) (key, value string, valid bool, err error) {
+	raw, ok := row[column]
+	key = column
+	if !ok {
+		for k, v := range row {
+			if strings.EqualFold(k, column) {
+				key, raw, ok = k, v, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return "", "", false, fmt.Errorf("typedb: discriminator column %q is missing from the result", column)
+	}
+
+	switch v := raw.(type) {
+	case nil:
+		return key, "", false, nil
+	case []byte:
+		return key, string(v), true, nil
+	default:
+		return key, fmt.Sprint(v), true, nil
+	}
+}
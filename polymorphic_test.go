@@ -0,0 +1,159 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// PolyTestEvent is the polymorphic type for discriminator tests
+type PolyTestEvent interface {
+	EventID() int64
+}
+
+// PolyTestClick is a variant without a field for the discriminator
+type PolyTestClick struct {
+	Model
+	X  int   `db:"x"`
+	ID int64 `db:"id" load:"primary"`
+}
+
+func (e *PolyTestClick) EventID() int64 { return e.ID }
+
+func (e *PolyTestClick) QueryByID() string {
+	return "SELECT id, x FROM events WHERE id = ?"
+}
+
+// PolyTestView is a variant that keeps the discriminator
+type PolyTestView struct {
+	Model
+	URL  string `db:"url"`
+	Kind string `db:"kind"`
+	ID   int64  `db:"id" load:"primary"`
+}
+
+func (e *PolyTestView) EventID() int64 { return e.ID }
+
+func (e *PolyTestView) QueryByID() string {
+	return "SELECT id, kind, url FROM events WHERE id = ?"
+}
+
+func registerPolyTestEvent(skipUnknown bool) {
+	RegisterDiscriminator[PolyTestEvent](Discriminator{
+		Column: "kind",
+		Variants: map[string]ModelInterface{
+			"click": (*PolyTestClick)(nil),
+			"view":  (*PolyTestView)(nil),
+		},
+		SkipUnknown: skipUnknown,
+	})
+}
+
+func TestQueryPolymorphic_SQLite(t *testing.T) {
+	registerPolyTestEvent(false)
+
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := WithColumnPolicyOverride(context.Background(), ColumnPolicyErrorOnUnknown)
+	for _, stmt := range []string{
+		"CREATE TABLE events (id INTEGER PRIMARY KEY, kind TEXT, x INTEGER, url TEXT)",
+		"INSERT INTO events (id, kind, x) VALUES (1, 'click', 42)",
+		"INSERT INTO events (id, kind, url) VALUES (2, 'view', '/home')",
+	} {
+		if _, err := db.Exec(ctx, stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+
+	events, err := QueryPolymorphic[PolyTestEvent](ctx, db, `
+		SELECT id, kind, x, NULL AS url FROM events WHERE kind = 'click'
+		UNION ALL
+		SELECT id, kind, NULL AS x, url FROM events WHERE kind = 'view'
+		ORDER BY id`)
+	if err == nil || !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("Expected the unused variant columns to fail ErrorOnUnknown, got %v", err)
+	}
+
+	events, err = QueryPolymorphic[PolyTestEvent](context.Background(), db, "SELECT id, kind, x, url FROM events ORDER BY id")
+	if err != nil {
+		t.Fatalf("QueryPolymorphic failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	click, ok := events[0].(*PolyTestClick)
+	if !ok || click.ID != 1 || click.X != 42 {
+		t.Errorf("Expected *PolyTestClick{ID: 1, X: 42}, got %#v", events[0])
+	}
+	view, ok := events[1].(*PolyTestView)
+	if !ok || view.ID != 2 || view.URL != "/home" || view.Kind != "view" {
+		t.Errorf("Expected *PolyTestView with kind, got %#v", events[1])
+	}
+
+	views, err := QueryPolymorphic[PolyTestEvent](ctx, db, "SELECT id, kind, url FROM events WHERE kind = 'view'")
+	if err != nil || len(views) != 1 {
+		t.Errorf("Expected the discriminator column to be accepted under ErrorOnUnknown, got %v, %v", views, err)
+	}
+}
+
+func TestQueryPolymorphic_UnknownValues(t *testing.T) {
+	mock := &MockExecutor{
+		QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+			return []map[string]any{
+				{"id": int64(1), "kind": []byte("click"), "x": int64(3)},
+				{"id": int64(2), "kind": "purchase"},
+				{"id": int64(3), "kind": nil},
+			}, nil
+		},
+	}
+	ctx := context.Background()
+
+	registerPolyTestEvent(false)
+	_, err := QueryPolymorphic[PolyTestEvent](ctx, mock, "SELECT ...")
+	if !errors.Is(err, ErrUnknownDiscriminator) || !strings.Contains(err.Error(), `"purchase"`) {
+		t.Errorf("Expected ErrUnknownDiscriminator naming the value, got %v", err)
+	}
+
+	registerPolyTestEvent(true)
+	defer registerPolyTestEvent(false)
+	events, err := QueryPolymorphic[PolyTestEvent](ctx, mock, "SELECT ...")
+	if err != nil {
+		t.Fatalf("QueryPolymorphic failed: %v", err)
+	}
+	if len(events) != 1 || events[0].EventID() != 1 {
+		t.Errorf("Expected unknown and NULL kinds to be skipped, got %+v", events)
+	}
+
+	_, err = QueryPolymorphic[PolyTestEvent](ctx, aggregateTestRows(map[string]any{"id": int64(1)}), "SELECT ...")
+	if err == nil || !strings.Contains(err.Error(), "discriminator column") {
+		t.Errorf("Expected missing discriminator column error, got %v", err)
+	}
+
+	_, err = QueryPolymorphic[error](ctx, mock, "SELECT ...")
+	if err == nil || !strings.Contains(err.Error(), "no discriminator registered") {
+		t.Errorf("Expected unregistered type error, got %v", err)
+	}
+}
+
+func TestRegisterDiscriminator_Panics(t *testing.T) {
+	tests := map[string]Discriminator{
+		"no column":        {Variants: map[string]ModelInterface{"click": (*PolyTestClick)(nil)}},
+		"not implementing": {Column: "kind", Variants: map[string]ModelInterface{"user": (*TupleTestUser)(nil)}},
+		"nil variant":      {Column: "kind", Variants: map[string]ModelInterface{"none": nil}},
+	}
+	for name, d := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			RegisterDiscriminator[PolyTestEvent](d)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package typedb
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 )
 
 // QueryAll executes a query and returns all rows as a slice of model pointers.
@@ -12,7 +14,7 @@ import (
 //
 //	users, err := typedb.QueryAll[*User](ctx, db, "SELECT id, name, email FROM users")
 func QueryAll[T ModelInterface](ctx context.Context, exec Executor, query string, args ...any) ([]T, error) {
-	rows, err := exec.QueryAll(ctx, query, args...)
+	rows, err := exec.QueryAll(withDuplicateColumnKeys(ctx), query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -21,9 +23,10 @@ func QueryAll[T ModelInterface](ctx context.Context, exec Executor, query string
 		return []T{}, nil
 	}
 
+	opts := newDeserializeOptions(ctx, exec)
 	result := make([]T, 0, len(rows))
 	for _, row := range rows {
-		model, err := deserializeForType[T](row)
+		model, err := deserializeForTypeWithOptions[T](row, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -44,7 +47,7 @@ func QueryAll[T ModelInterface](ctx context.Context, exec Executor, query string
 //	    // No user found
 //	}
 func QueryFirst[T ModelInterface](ctx context.Context, exec Executor, query string, args ...any) (T, error) {
-	row, err := exec.QueryRowMap(ctx, query, args...)
+	row, err := exec.QueryRowMap(withDuplicateColumnKeys(ctx), query, args...)
 	if err != nil {
 		if err == ErrNotFound {
 			var zero T
@@ -54,7 +57,7 @@ func QueryFirst[T ModelInterface](ctx context.Context, exec Executor, query stri
 		return zero, err
 	}
 
-	model, err := deserializeForType[T](row)
+	model, err := deserializeForTypeWithOptions[T](row, newDeserializeOptions(ctx, exec))
 	if err != nil {
 		var zero T
 		return zero, err
@@ -75,13 +78,13 @@ func QueryFirst[T ModelInterface](ctx context.Context, exec Executor, query stri
 //	    // User not found
 //	}
 func QueryOne[T ModelInterface](ctx context.Context, exec Executor, query string, args ...any) (T, error) {
-	row, err := exec.QueryRowMap(ctx, query, args...)
+	row, err := exec.QueryRowMap(withDuplicateColumnKeys(ctx), query, args...)
 	if err != nil {
 		var zero T
 		return zero, err
 	}
 
-	model, err := deserializeForType[T](row)
+	model, err := deserializeForTypeWithOptions[T](row, newDeserializeOptions(ctx, exec))
 	if err != nil {
 		var zero T
 		return zero, err
@@ -89,3 +92,116 @@ func QueryOne[T ModelInterface](ctx context.Context, exec Executor, query string
 
 	return model, nil
 }
+
+// QueryMap executes a query and returns the rows keyed by the value of a struct field.
+// keyField is the Go field name to key by (e.g., "ID"); if empty, the field with the
+// load:"primary" tag is used. The field type must be assignable or convertible to K.
+// Returns an error wrapping ErrDuplicateKey if two rows produce the same key.
+// T must be a pointer type (e.g., *User).
+//
+// Example:
+//
+//	users, err := typedb.QueryMap[int64, *User](ctx, db, "", "SELECT id, name, email FROM users")
+//	// users is map[int64]*User keyed by User.ID
+func QueryMap[K comparable, T ModelInterface](ctx context.Context, exec Executor, keyField, query string, args ...any) (map[K]T, error) {
+	// Resolve the key field before the query, so a misspelled field fails without a round trip
+	fieldName, err := resolveKeyFieldName[T](keyField)
+	if err != nil {
+		return nil, fmt.Errorf("typedb: QueryMap: %w", err)
+	}
+
+	models, err := QueryAll[T](ctx, exec, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[K]T, len(models))
+	for _, model := range models {
+		key, err := extractKey[K](model, fieldName)
+		if err != nil {
+			return nil, fmt.Errorf("typedb: QueryMap: %w", err)
+		}
+		if _, exists := result[key]; exists {
+			return nil, fmt.Errorf("typedb: QueryMap: %w: %s = %v", ErrDuplicateKey, fieldName, key)
+		}
+		result[key] = model
+	}
+
+	return result, nil
+}
+
+// QueryGroup executes a query and returns the rows grouped by the value of a struct field.
+// keyField is the Go field name to group by (e.g., "UserID"); if empty, the field with the
+// load:"primary" tag is used. The field type must be assignable or convertible to K.
+// Rows within each group keep the order returned by the query.
+// T must be a pointer type (e.g., *Post).
+//
+// Example:
+//
+//	postsByUser, err := typedb.QueryGroup[int64, *Post](ctx, db, "UserID", "SELECT id, user_id, title FROM posts")
+//	// postsByUser is map[int64][]*Post
+func QueryGroup[K comparable, T ModelInterface](ctx context.Context, exec Executor, keyField, query string, args ...any) (map[K][]T, error) {
+	// Resolve the key field before the query, so a misspelled field fails without a round trip
+	fieldName, err := resolveKeyFieldName[T](keyField)
+	if err != nil {
+		return nil, fmt.Errorf("typedb: QueryGroup: %w", err)
+	}
+
+	models, err := QueryAll[T](ctx, exec, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[K][]T)
+	for _, model := range models {
+		key, err := extractKey[K](model, fieldName)
+		if err != nil {
+			return nil, fmt.Errorf("typedb: QueryGroup: %w", err)
+		}
+		result[key] = append(result[key], model)
+	}
+
+	return result, nil
+}
+
+// resolveKeyFieldName returns keyField, or the name of the load:"primary" field if keyField is empty.
+func resolveKeyFieldName[T ModelInterface](keyField string) (string, error) {
+	var model T
+	modelType := reflect.TypeOf(model)
+	if modelType == nil || modelType.Kind() != reflect.Ptr {
+		return "", fmt.Errorf("T must be a pointer type (e.g., *User)")
+	}
+
+	if keyField != "" {
+		if _, found := findFieldByNameRecursive(modelType.Elem(), keyField); !found {
+			return "", fmt.Errorf("%w: %s", ErrFieldNotFound, keyField)
+		}
+		return keyField, nil
+	}
+
+	primaryField, found := findFieldByTagRecursive(modelType.Elem(), "load", "primary")
+	if !found {
+		return "", fmt.Errorf("no key field given and no field with load:\"primary\" tag found")
+	}
+	return primaryField.Name, nil
+}
+
+// extractKey reads the named field from model and converts it to K.
+func extractKey[K comparable](model any, fieldName string) (K, error) {
+	var zero K
+	fieldValue, err := getFieldValue(model, fieldName)
+	if err != nil {
+		return zero, err
+	}
+
+	keyType := reflect.TypeOf((*K)(nil)).Elem()
+	if fieldValue.Type().AssignableTo(keyType) {
+		return fieldValue.Interface().(K), nil
+	}
+	// Named types convert to their underlying kind; string<->numeric conversions would produce runes, so they are rejected
+	if fieldValue.Type().ConvertibleTo(keyType) && (fieldValue.Kind() == reflect.String) == (keyType.Kind() == reflect.String) {
+		return fieldValue.Convert(keyType).Interface().(K), nil
+	}
+
+	return zero, fmt.Errorf("field %s of type %v cannot be used as key type %v", fieldName, fieldValue.Type(), keyType)
+}
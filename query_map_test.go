@@ -0,0 +1,220 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// QueryMapTestPost is a test model for QueryMap/QueryGroup tests
+type QueryMapTestPost struct {
+	Model
+	Title  string `db:"title"`
+	ID     int64  `db:"id" load:"primary"`
+	UserID int    `db:"user_id"`
+}
+
+func (p *QueryMapTestPost) QueryByID() string {
+	return "SELECT id, user_id, title FROM posts WHERE id = $1"
+}
+
+// QueryMapTestStatus is a named string type used as a QueryMap key
+type QueryMapTestStatus string
+
+// QueryMapTestStatusPost is a test model keyed by a named string field
+type QueryMapTestStatusPost struct {
+	Model
+	Status QueryMapTestStatus `db:"status"`
+	ID     int64              `db:"id" load:"primary"`
+}
+
+func (p *QueryMapTestStatusPost) QueryByID() string {
+	return "SELECT id, status FROM posts WHERE id = $1"
+}
+
+func queryMapTestRows() []map[string]any {
+	return []map[string]any{
+		{"id": int64(1), "user_id": int64(10), "title": "First"},
+		{"id": int64(2), "user_id": int64(20), "title": "Second"},
+		{"id": int64(3), "user_id": int64(10), "title": "Third"},
+	}
+}
+
+func TestQueryMap(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults to primary key field", func(t *testing.T) {
+		mock := &MockExecutor{
+			QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+				return queryMapTestRows(), nil
+			},
+		}
+
+		posts, err := QueryMap[int64, *QueryMapTestPost](ctx, mock, "", "SELECT id, user_id, title FROM posts")
+		if err != nil {
+			t.Fatalf("QueryMap failed: %v", err)
+		}
+		if len(posts) != 3 {
+			t.Fatalf("Expected 3 posts, got %d", len(posts))
+		}
+		if posts[2].Title != "Second" {
+			t.Errorf("Expected posts[2].Title = Second, got %q", posts[2].Title)
+		}
+	})
+
+	t.Run("converts field type to key type", func(t *testing.T) {
+		mock := &MockExecutor{
+			QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+				return queryMapTestRows()[:2], nil
+			},
+		}
+
+		posts, err := QueryMap[int, *QueryMapTestPost](ctx, mock, "ID", "SELECT id, user_id, title FROM posts")
+		if err != nil {
+			t.Fatalf("QueryMap failed: %v", err)
+		}
+		if posts[1].Title != "First" {
+			t.Errorf("Expected posts[1].Title = First, got %q", posts[1].Title)
+		}
+	})
+
+	t.Run("converts named string field to string key", func(t *testing.T) {
+		mock := &MockExecutor{
+			QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+				return []map[string]any{
+					{"id": int64(1), "status": "draft"},
+					{"id": int64(2), "status": "published"},
+				}, nil
+			},
+		}
+
+		posts, err := QueryMap[string, *QueryMapTestStatusPost](ctx, mock, "Status", "SELECT id, status FROM posts")
+		if err != nil {
+			t.Fatalf("QueryMap failed: %v", err)
+		}
+		if posts["published"] == nil || posts["published"].ID != 2 {
+			t.Errorf("Expected published post with ID 2, got %+v", posts["published"])
+		}
+	})
+
+	t.Run("duplicate key names the key", func(t *testing.T) {
+		mock := &MockExecutor{
+			QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+				return queryMapTestRows(), nil
+			},
+		}
+
+		_, err := QueryMap[int, *QueryMapTestPost](ctx, mock, "UserID", "SELECT id, user_id, title FROM posts")
+		if !errors.Is(err, ErrDuplicateKey) {
+			t.Fatalf("Expected ErrDuplicateKey, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "UserID = 10") {
+			t.Errorf("Expected error to name the key, got %v", err)
+		}
+	})
+
+	t.Run("unknown key field", func(t *testing.T) {
+		queried := false
+		mock := &MockExecutor{
+			QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+				queried = true
+				return queryMapTestRows(), nil
+			},
+		}
+
+		_, err := QueryMap[int, *QueryMapTestPost](ctx, mock, "Missing", "SELECT id FROM posts")
+		if !errors.Is(err, ErrFieldNotFound) {
+			t.Fatalf("Expected ErrFieldNotFound, got %v", err)
+		}
+		_, err = QueryGroup[int, *QueryMapTestPost](ctx, mock, "Missing", "SELECT id FROM posts")
+		if !errors.Is(err, ErrFieldNotFound) {
+			t.Fatalf("Expected ErrFieldNotFound from QueryGroup, got %v", err)
+		}
+		if queried {
+			t.Error("Expected the key field to be validated before running the query")
+		}
+	})
+
+	t.Run("incompatible key type", func(t *testing.T) {
+		mock := &MockExecutor{
+			QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+				return queryMapTestRows(), nil
+			},
+		}
+
+		_, err := QueryMap[string, *QueryMapTestPost](ctx, mock, "", "SELECT id FROM posts")
+		if err == nil || !strings.Contains(err.Error(), "cannot be used as key type") {
+			t.Fatalf("Expected key type error, got %v", err)
+		}
+	})
+
+	t.Run("no primary key field", func(t *testing.T) {
+		mock := &MockExecutor{
+			QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+				return []map[string]any{{"id": int64(1), "name": "Alice"}}, nil
+			},
+		}
+
+		_, err := QueryMap[int, *QueryTestUser](ctx, mock, "", "SELECT id, name FROM users")
+		if err == nil || !strings.Contains(err.Error(), "load:\"primary\"") {
+			t.Fatalf("Expected missing primary key error, got %v", err)
+		}
+	})
+
+	t.Run("error from executor", func(t *testing.T) {
+		expectedErr := errors.New("database error")
+		mock := &MockExecutor{
+			QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+				return nil, expectedErr
+			},
+		}
+
+		_, err := QueryMap[int64, *QueryMapTestPost](ctx, mock, "", "SELECT id FROM posts")
+		if err != expectedErr {
+			t.Fatalf("Expected error %v, got %v", expectedErr, err)
+		}
+	})
+}
+
+func TestQueryGroup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("groups rows preserving order", func(t *testing.T) {
+		mock := &MockExecutor{
+			QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+				return queryMapTestRows(), nil
+			},
+		}
+
+		groups, err := QueryGroup[int, *QueryMapTestPost](ctx, mock, "UserID", "SELECT id, user_id, title FROM posts")
+		if err != nil {
+			t.Fatalf("QueryGroup failed: %v", err)
+		}
+		if len(groups) != 2 {
+			t.Fatalf("Expected 2 groups, got %d", len(groups))
+		}
+		if len(groups[10]) != 2 || groups[10][0].ID != 1 || groups[10][1].ID != 3 {
+			t.Errorf("Unexpected group for user 10: %+v", groups[10])
+		}
+		if len(groups[20]) != 1 || groups[20][0].ID != 2 {
+			t.Errorf("Unexpected group for user 20: %+v", groups[20])
+		}
+	})
+
+	t.Run("empty result", func(t *testing.T) {
+		mock := &MockExecutor{
+			QueryAllFunc: func(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+				return []map[string]any{}, nil
+			},
+		}
+
+		groups, err := QueryGroup[int64, *QueryMapTestPost](ctx, mock, "", "SELECT id FROM posts")
+		if err != nil {
+			t.Fatalf("QueryGroup failed: %v", err)
+		}
+		if groups == nil || len(groups) != 0 {
+			t.Fatalf("Expected empty map, got %v", groups)
+		}
+	})
+}
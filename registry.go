@@ -12,6 +12,10 @@ type ModelOptions struct {
 	// When enabled, Update() will only update fields that have changed since the last deserialization.
 	// This requires keeping a copy of the deserialized object, which uses additional memory.
 	PartialUpdate bool
+
+	// ColumnPolicy controls how unmapped result columns and missing db-tagged fields are handled
+	// when deserializing this model. Zero inherits the DB's policy (see WithColumnPolicy).
+	ColumnPolicy ColumnPolicy
 }
 
 var (
@@ -100,6 +104,9 @@ func RegisterModelWithOptions[T ModelInterface](opts ModelOptions) {
 		defaultLogger.Error("Model registration validation failed", "model", t.Name(), "error", err)
 		panic(fmt.Errorf("typedb: validation failed for model %s during registration: %w", t.Name(), err))
 	}
+	if opts.ColumnPolicy.has(ColumnPolicyCollect) && !hasCollectField(t) {
+		panic(fmt.Errorf("typedb: model %s uses ColumnPolicyCollect but has no map[string]any field tagged db:\"*\"", t.Name()))
+	}
 
 	registerMutex.Lock()
 	defer registerMutex.Unlock()
@@ -0,0 +1,483 @@
+package typedb
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Relation kinds used in rel tags.
+const (
+	relHasMany    = "has_many"
+	relBelongsTo  = "belongs_to"
+	relManyToMany = "many_to_many"
+)
+
+var modelInterfaceType = reflect.TypeOf((*ModelInterface)(nil)).Elem()
+
+// relation describes a field tagged with rel:"...".
+type relation struct {
+	targetType  reflect.Type // The related model's struct type
+	kind        string       // relHasMany, relBelongsTo or relManyToMany
+	fieldName   string       // The relation field on the owning model
+	foreignKey  string       // The fk column
+	method      string       // The query method on the related model, e.g. QueryByUserIDs
+	ownerKey    string       // Name of the owner's field holding the key (primary key, or fk field for belongs_to)
+	ownerIndex  []int        // Index path of the ownerKey field
+	groupColumn string       // Result column matching the owner's key (fk, or the target's primary key for belongs_to)
+	fieldIndex  []int        // Index path of the relation field
+	slice       bool         // True for slice fields
+	pointer     bool         // True for *T fields and []*T elements
+}
+
+// relationsCache maps "<type>.<field>" to its parsed relation or error.
+var relationsCache sync.Map // map[relationCacheKey]relationCacheEntry
+
+type relationCacheKey struct {
+	t     reflect.Type
+	field string
+}
+
+type relationCacheEntry struct {
+	rel *relation
+	err error
+}
+
+// getRelation returns the relation declared by the named field of a struct type.
+func getRelation(t reflect.Type, fieldName string) (*relation, error) {
+	key := relationCacheKey{t: t, field: fieldName}
+	if cached, ok := relationsCache.Load(key); ok {
+		entry := cached.(relationCacheEntry)
+		return entry.rel, entry.err
+	}
+
+	rel, err := parseRelation(t, fieldName)
+	relationsCache.Store(key, relationCacheEntry{rel: rel, err: err})
+	return rel, err
+}
+
+// parseRelation parses and validates the rel tag of the named field of t.
+func parseRelation(t reflect.Type, fieldName string) (*relation, error) {
+	field, found := t.FieldByName(fieldName)
+	if !found {
+		return nil, fmt.Errorf("%w: %s.%s", ErrFieldNotFound, t.Name(), fieldName)
+	}
+	tag := field.Tag.Get("rel")
+	if tag == "" {
+		return nil, fmt.Errorf("field %s has no rel tag", fieldName)
+	}
+
+	rel := &relation{fieldName: fieldName, fieldIndex: field.Index}
+	parts := splitTag(tag)
+	rel.kind = parts[0]
+	for _, part := range parts[1:] {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("field %s: invalid rel option %q", fieldName, part)
+		}
+		switch name {
+		case "fk":
+			rel.foreignKey = value
+		case "through":
+			// The join is written in the related model's query method, so the table name would go unused
+			return nil, fmt.Errorf("field %s: rel option through is not supported; join the join table in the related model's query method", fieldName)
+		default:
+			return nil, fmt.Errorf("field %s: unknown rel option %q", fieldName, name)
+		}
+	}
+
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Slice {
+		rel.slice = true
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() == reflect.Ptr {
+		rel.pointer = true
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct || !reflect.PointerTo(fieldType).Implements(modelInterfaceType) {
+		return nil, fmt.Errorf("field %s: rel fields must hold models (embedding typedb.Model), got %v", fieldName, field.Type)
+	}
+	rel.targetType = fieldType
+
+	switch rel.kind {
+	case relHasMany, relManyToMany:
+		if !rel.slice {
+			return nil, fmt.Errorf("field %s: %s relation must be a slice, got %v", fieldName, rel.kind, field.Type)
+		}
+		if rel.foreignKey == "" {
+			rel.foreignKey = toSnakeCase(t.Name()) + "_id"
+		}
+		primaryField, found := findFieldByTagRecursive(t, "load", "primary")
+		if !found {
+			return nil, fmt.Errorf("field %s: %s relation requires a load:\"primary\" field on %s", fieldName, rel.kind, t.Name())
+		}
+		rel.ownerKey = primaryField.Name
+		rel.groupColumn = rel.foreignKey
+
+		methodField := columnToFieldName(rel.foreignKey)
+		if rel.kind == relHasMany {
+			fkField, found := findFieldByTagRecursive(rel.targetType, "db", rel.foreignKey)
+			if !found {
+				return nil, fmt.Errorf("field %s: %s has no field tagged db:%q", fieldName, rel.targetType.Name(), rel.foreignKey)
+			}
+			methodField = fkField.Name
+		}
+		rel.method = "QueryBy" + methodField + "s"
+
+	case relBelongsTo:
+		if rel.slice {
+			return nil, fmt.Errorf("field %s: belongs_to relation must be a struct or pointer, got %v", fieldName, field.Type)
+		}
+		if rel.foreignKey == "" {
+			rel.foreignKey = toSnakeCase(fieldName) + "_id"
+		}
+		fkField, found := findFieldByTagRecursive(t, "db", rel.foreignKey)
+		if !found {
+			return nil, fmt.Errorf("field %s: %s has no field tagged db:%q", fieldName, t.Name(), rel.foreignKey)
+		}
+		rel.ownerKey = fkField.Name
+		primaryField, found := findFieldByTagRecursive(rel.targetType, "load", "primary")
+		if !found {
+			return nil, fmt.Errorf("field %s: belongs_to relation requires a load:\"primary\" field on %s", fieldName, rel.targetType.Name())
+		}
+		rel.groupColumn = primaryField.Tag.Get("db")
+		rel.method = "QueryBy" + primaryField.Name + "s"
+
+	default:
+		return nil, fmt.Errorf("field %s: unknown relation %q (want has_many, belongs_to or many_to_many)", fieldName, rel.kind)
+	}
+
+	if err := validateQueryMethod(reflect.New(rel.targetType).Interface(), rel.method); err != nil {
+		return nil, fmt.Errorf("field %s: %s: %w", fieldName, rel.targetType.Name(), err)
+	}
+
+	ownerField, _ := t.FieldByName(rel.ownerKey)
+	rel.ownerIndex = ownerField.Index
+
+	return rel, nil
+}
+
+// validateRelationFields validates the rel tags of a model type, including those of embedded structs.
+func validateRelationFields(t reflect.Type) []string {
+	var errors []string
+	var collect func(reflect.Type)
+	collect = func(structType reflect.Type) {
+		for i := 0; i < structType.NumField(); i++ {
+			field := structType.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous && field.Tag.Get("rel") == "" {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					collect(embeddedType)
+					continue
+				}
+			}
+			if field.Tag.Get("rel") == "" {
+				continue
+			}
+			if _, err := getRelation(t, field.Name); err != nil {
+				errors = append(errors, err.Error())
+			}
+		}
+	}
+	collect(t)
+	return errors
+}
+
+// Preload loads the relations named by paths into every model of models, running one query per
+// relation and nesting level instead of one query per model.
+//
+// Relations are declared with rel tags on fields holding other models:
+//
+//	rel:"has_many,fk=user_id"                 // []Post: posts.user_id references this model's primary key
+//	rel:"belongs_to,fk=user_id"               // *User: this model's user_id references users' primary key
+//	rel:"many_to_many,fk=user_id"             // []Role: joined through a join table's user_id
+//
+// fk defaults to "<model>_id" (has_many, many_to_many) or "<field>_id" (belongs_to), in snake case.
+// The query comes from a method on the related model, named after the key it filters by:
+// QueryBy{FkField}s() for has_many (FkField is the related model's field tagged with fk),
+// QueryBy{Fk}s() for many_to_many (e.g., QueryByUserIDs for user_id), and QueryBy{PrimaryField}s()
+// for belongs_to. The query must have a single placeholder for the key list, which Preload expands
+// to one placeholder per key (e.g., "WHERE user_id IN (?)" or "WHERE user_id IN ($1)"). Keys beyond
+// the driver's bind parameter limit (999 on SQLite, 2099 on SQL Server) are split across queries.
+// many_to_many queries must also select the fk column of the join table.
+//
+// Paths use field names separated by dots; "Posts.Comments" loads Posts, then the Comments of every post.
+// Loaded slices are replaced (empty when nothing matches); belongs_to pointers are nil when nothing matches.
+//
+// Example:
+//
+//	users, err := typedb.QueryAll[*User](ctx, db, "SELECT id, name FROM users")
+//	err = typedb.Preload(ctx, db, users, "Posts", "Posts.Comments")
+func Preload[T ModelInterface](ctx context.Context, exec Executor, models []T, paths ...string) error {
+	var zero T
+	modelType := reflect.TypeOf(zero)
+	if modelType == nil || modelType.Kind() != reflect.Ptr || modelType.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("typedb: Preload requires a pointer to struct type")
+	}
+
+	owners := make([]reflect.Value, 0, len(models))
+	for _, model := range models {
+		v := reflect.ValueOf(model)
+		if !v.IsNil() {
+			owners = append(owners, v)
+		}
+	}
+
+	return preloadPaths(ctx, exec, owners, modelType.Elem(), buildPreloadTree(paths))
+}
+
+// preloadTree maps a relation field name to the relations to load below it.
+type preloadTree map[string]preloadTree
+
+// buildPreloadTree merges dotted paths into a tree, so shared prefixes are loaded once.
+func buildPreloadTree(paths []string) preloadTree {
+	tree := preloadTree{}
+	for _, path := range paths {
+		node := tree
+		for _, name := range strings.Split(path, ".") {
+			child, ok := node[name]
+			if !ok {
+				child = preloadTree{}
+				node[name] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+// preloadPaths loads each relation of tree into owners, then recurses into the loaded models.
+func preloadPaths(ctx context.Context, exec Executor, owners []reflect.Value, ownerType reflect.Type, tree preloadTree) error {
+	for name, children := range tree {
+		rel, err := getRelation(ownerType, name)
+		if err != nil {
+			return fmt.Errorf("typedb: Preload %s: %w", ownerType.Name(), err)
+		}
+		loaded, err := preloadRelation(ctx, exec, owners, rel)
+		if err != nil {
+			return fmt.Errorf("typedb: Preload %s.%s: %w", ownerType.Name(), name, err)
+		}
+		if len(children) > 0 && len(loaded) > 0 {
+			if err := preloadPaths(ctx, exec, loaded, rel.targetType, children); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// preloadRelation runs the relation's query for the keys of all owners, assigns the results to
+// each owner's relation field, and returns pointers to the loaded models.
+func preloadRelation(ctx context.Context, exec Executor, owners []reflect.Value, rel *relation) ([]reflect.Value, error) {
+	serializeOpts := newSerializeOptions(exec)
+	ownerKeys := make([]string, len(owners))
+	var args []any
+	seen := make(map[string]bool)
+	for i, owner := range owners {
+		keyValue, err := owner.Elem().FieldByIndexErr(rel.ownerIndex)
+		if err != nil || isZeroOrNil(keyValue) {
+			// A nil embedded struct holds no key
+			continue
+		}
+		arg, err := serializeFieldValue(keyValue, serializeOpts)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", rel.ownerKey, err)
+		}
+		// Pointer and Null[T] keys are reduced to their value, so they match the related model's key
+		arg, err = driver.DefaultParameterConverter.ConvertValue(arg)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", rel.ownerKey, err)
+		}
+		if arg == nil {
+			continue
+		}
+		ownerKeys[i] = relationKey(arg)
+		if !seen[ownerKeys[i]] {
+			seen[ownerKeys[i]] = true
+			args = append(args, arg)
+		}
+	}
+
+	matches := make(map[string][]reflect.Value)
+	query := reflect.New(rel.targetType).MethodByName(rel.method).Call(nil)[0].String()
+	driverName := getDriverName(exec)
+	opts := newDeserializeOptions(ctx, exec)
+	var groupField bool
+	if rel.kind == relManyToMany {
+		_, groupField = findFieldByTagRecursive(rel.targetType, "db", rel.groupColumn)
+	}
+
+	// Split the keys so each query stays under the driver's bind parameter limit
+	chunkSize := maxBindParameters(driverName)
+	for start := 0; start < len(args); start += chunkSize {
+		end := start + chunkSize
+		if end > len(args) {
+			end = len(args)
+		}
+		chunkQuery, err := expandListPlaceholder(query, driverName, end-start)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s(): %w", rel.targetType.Name(), rel.method, err)
+		}
+
+		rows, err := exec.QueryAll(withDuplicateColumnKeys(ctx), chunkQuery, args[start:end]...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			groupValue, ok := row[rel.groupColumn]
+			if !ok {
+				return nil, fmt.Errorf("%s.%s() result has no %q column", rel.targetType.Name(), rel.method, rel.groupColumn)
+			}
+			if rel.kind == relManyToMany && !groupField {
+				// The join table column is not part of the related model
+				delete(row, rel.groupColumn)
+			}
+
+			target := reflect.New(rel.targetType)
+			if err := deserializeWithOptions(row, target.Interface().(ModelInterface), opts); err != nil {
+				return nil, err
+			}
+			key := relationKey(groupValue)
+			matches[key] = append(matches[key], target)
+		}
+	}
+
+	var loaded []reflect.Value
+	seenLoaded := make(map[uintptr]bool)
+	for i, owner := range owners {
+		field := fieldByIndexAlloc(owner.Elem(), rel.fieldIndex)
+		var found []reflect.Value
+		if ownerKeys[i] != "" {
+			found = matches[ownerKeys[i]]
+		}
+
+		if rel.slice {
+			slice := reflect.MakeSlice(field.Type(), 0, len(found))
+			for _, target := range found {
+				if rel.pointer {
+					slice = reflect.Append(slice, target)
+				} else {
+					slice = reflect.Append(slice, target.Elem())
+				}
+			}
+			field.Set(slice)
+			for j := 0; j < slice.Len(); j++ {
+				elem := slice.Index(j)
+				if !rel.pointer {
+					elem = elem.Addr()
+				}
+				loaded = appendUnique(loaded, seenLoaded, elem)
+			}
+			continue
+		}
+
+		switch {
+		case len(found) == 0:
+			field.Set(reflect.Zero(field.Type()))
+		case rel.pointer:
+			field.Set(found[0])
+			loaded = appendUnique(loaded, seenLoaded, found[0])
+		default:
+			field.Set(found[0].Elem())
+			loaded = appendUnique(loaded, seenLoaded, field.Addr())
+		}
+	}
+
+	return loaded, nil
+}
+
+// appendUnique appends ptr to values unless it was already appended.
+func appendUnique(values []reflect.Value, seen map[uintptr]bool, ptr reflect.Value) []reflect.Value {
+	if seen[ptr.Pointer()] {
+		return values
+	}
+	seen[ptr.Pointer()] = true
+	return append(values, ptr)
+}
+
+// fieldByIndexAlloc returns the field of v at index, allocating nil embedded struct pointers on the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// relationKey returns a string identifying a key value independently of its integer or byte representation.
+func relationKey(value any) string {
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(value)
+}
+
+// expandListPlaceholder replaces the single placeholder of query with n comma-separated placeholders.
+// Placeholder-like text in string literals, quoted identifiers and comments is left alone.
+func expandListPlaceholder(query, driverName string, n int) (string, error) {
+	first := generatePlaceholder(driverName, 1)
+	index := indexPlaceholder(query, first)
+	if index == -1 || countPlaceholders(query) != 1 {
+		return "", fmt.Errorf("query must have exactly one %s placeholder for the key list", first)
+	}
+
+	placeholders := make([]string, n)
+	for i := range placeholders {
+		placeholders[i] = generatePlaceholder(driverName, i+1)
+	}
+	return query[:index] + strings.Join(placeholders, ", ") + query[index+len(first):], nil
+}
+
+// toSnakeCase converts a Go identifier such as "BlogPost" or "UserID" to "blog_post" or "user_id".
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		upper := r >= 'A' && r <= 'Z'
+		if upper && i > 0 {
+			prevLower := runes[i-1] >= 'a' && runes[i-1] <= 'z'
+			nextLower := i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z'
+			prevUpper := runes[i-1] >= 'A' && runes[i-1] <= 'Z'
+			if prevLower || (prevUpper && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		if upper {
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// columnToFieldName converts a snake case column such as "user_id" to a Go field name such as "UserID".
+func columnToFieldName(column string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(column, "_") {
+		if part == "" {
+			continue
+		}
+		if strings.EqualFold(part, "id") {
+			b.WriteString("ID")
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,387 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// RelTestUser is a test model with has_many and many_to_many relations
+type RelTestUser struct {
+	Model
+	Posts []*RelTestPost `rel:"has_many,fk=user_id"`
+	Roles []RelTestRole  `rel:"many_to_many,fk=user_id"`
+	Name  string         `db:"name"`
+	ID    int64          `db:"id" load:"primary"`
+}
+
+func (u *RelTestUser) QueryByID() string {
+	return "SELECT id, name FROM users WHERE id = ?"
+}
+
+func (u *RelTestUser) QueryByIDs() string {
+	return "SELECT id, name FROM users WHERE id IN (?)"
+}
+
+// RelTestPost is a test model with belongs_to and has_many relations
+type RelTestPost struct {
+	Model
+	Author   *RelTestUser      `rel:"belongs_to,fk=user_id"`
+	Comments []*RelTestComment `rel:"has_many,fk=post_id"`
+	Title    string            `db:"title"`
+	UserID   int64             `db:"user_id"`
+	ID       int64             `db:"id" load:"primary"`
+}
+
+func (p *RelTestPost) QueryByID() string {
+	return "SELECT id, user_id, title FROM posts WHERE id = ?"
+}
+
+func (p *RelTestPost) QueryByUserIDs() string {
+	return "SELECT id, user_id, title FROM posts WHERE user_id IN (?) ORDER BY id"
+}
+
+// RelTestComment is a test model loaded two levels deep
+type RelTestComment struct {
+	Model
+	Body   string `db:"body"`
+	PostID int64  `db:"post_id"`
+	ID     int64  `db:"id" load:"primary"`
+}
+
+func (c *RelTestComment) QueryByID() string {
+	return "SELECT id, post_id, body FROM comments WHERE id = ?"
+}
+
+func (c *RelTestComment) QueryByPostIDs() string {
+	return "SELECT id, post_id, body FROM comments WHERE post_id IN (?) ORDER BY id"
+}
+
+// RelTestRole is a test model related through a join table
+type RelTestRole struct {
+	Model
+	Name string `db:"name"`
+	ID   int64  `db:"id" load:"primary"`
+}
+
+func (r *RelTestRole) QueryByID() string {
+	return "SELECT id, name FROM roles WHERE id = ?"
+}
+
+func (r *RelTestRole) QueryByUserIDs() string {
+	return "SELECT r.id, r.name, ur.user_id FROM roles r JOIN user_roles ur ON ur.role_id = r.id WHERE ur.user_id IN (?) ORDER BY r.id"
+}
+
+// RelTestInvalid is a test model with invalid rel tags
+type RelTestInvalid struct {
+	Model
+	Posts    []*RelTestPost `rel:"has_many,fk=author_id"`
+	Comments []RelTestRole  `rel:"many_to_many,through=user_roles"`
+	Owner    RelTestComment `rel:"belongs_to"`
+	Kind     []RelTestRole  `rel:"has_one"`
+	OwnerID  int64          `db:"owner_id"`
+	ID       int64          `db:"id" load:"primary"`
+}
+
+func (m *RelTestInvalid) QueryByID() string {
+	return "SELECT id FROM invalid WHERE id = ?"
+}
+
+// RelTestDraft is a test model with nullable belongs_to keys
+type RelTestDraft struct {
+	Model
+	Author   *RelTestUser `rel:"belongs_to,fk=user_id"`
+	Editor   *RelTestUser `rel:"belongs_to,fk=editor_id"`
+	UserID   *int64       `db:"user_id"`
+	EditorID Null[int64]  `db:"editor_id"`
+	ID       int64        `db:"id" load:"primary"`
+}
+
+func (d *RelTestDraft) QueryByID() string {
+	return "SELECT id, user_id, editor_id FROM drafts WHERE id = ?"
+}
+
+// RelTestUserPosts holds a relation declared on an embedded struct
+type RelTestUserPosts struct {
+	Posts []*RelTestPost `rel:"has_many,fk=user_id"`
+}
+
+// RelTestEmbeddingUser is a test model whose relation comes from an embedded struct pointer
+type RelTestEmbeddingUser struct {
+	Model
+	*RelTestUserPosts
+	ID int64 `db:"id" load:"primary"`
+}
+
+func (u *RelTestEmbeddingUser) QueryByID() string {
+	return "SELECT id FROM users WHERE id = ?"
+}
+
+// RelTestBadRelations holds an invalid relation declared on an embedded struct
+type RelTestBadRelations struct {
+	Kind []RelTestRole `rel:"has_one"`
+}
+
+// RelTestInvalidEmbedded is a test model embedding an invalid relation
+type RelTestInvalidEmbedded struct {
+	Model
+	RelTestBadRelations
+	ID int64 `db:"id" load:"primary"`
+}
+
+func (m *RelTestInvalidEmbedded) QueryByID() string {
+	return "SELECT id FROM invalid WHERE id = ?"
+}
+
+func TestValidateModel_Relations(t *testing.T) {
+	for _, model := range []ModelInterface{&RelTestUser{}, &RelTestPost{}} {
+		if err := ValidateModel(model); err != nil {
+			t.Errorf("Expected valid relations, got %v", err)
+		}
+	}
+
+	err := ValidateModel(&RelTestInvalid{})
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+	for _, want := range []string{
+		`RelTestPost has no field tagged db:"author_id"`,
+		"rel option through is not supported",
+		"QueryByIDs() method not found",
+		`unknown relation "has_one"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error containing %q, got %v", want, err)
+		}
+	}
+
+	if err := ValidateModel(&RelTestEmbeddingUser{}); err != nil {
+		t.Errorf("Expected valid embedded relation, got %v", err)
+	}
+	err = ValidateModel(&RelTestInvalidEmbedded{})
+	if err == nil || !strings.Contains(err.Error(), `unknown relation "has_one"`) {
+		t.Errorf("Expected embedded relation to be validated, got %v", err)
+	}
+}
+
+func TestExpandListPlaceholder(t *testing.T) {
+	tests := []struct {
+		driver string
+		query  string
+		want   string
+	}{
+		{driver: "sqlite3", query: "WHERE id IN (?)", want: "WHERE id IN (?, ?, ?)"},
+		{driver: "postgres", query: "WHERE id IN ($1)", want: "WHERE id IN ($1, $2, $3)"},
+		{driver: "sqlserver", query: "WHERE id IN (@p1)", want: "WHERE id IN (@p1, @p2, @p3)"},
+		{driver: "oracle", query: "WHERE id IN (:1)", want: "WHERE id IN (:1, :2, :3)"},
+	}
+	for _, tt := range tests {
+		got, err := expandListPlaceholder(tt.query, tt.driver, 3)
+		if err != nil || got != tt.want {
+			t.Errorf("expandListPlaceholder(%q, %s) = %q, %v; want %q", tt.query, tt.driver, got, err, tt.want)
+		}
+	}
+
+	if _, err := expandListPlaceholder("WHERE a = ? AND id IN (?)", "mysql", 2); err == nil {
+		t.Error("Expected error for multiple placeholders")
+	}
+	if _, err := expandListPlaceholder("WHERE id IN ($1) AND a = $2", "postgres", 2); err == nil {
+		t.Error("Expected error for multiple placeholders")
+	}
+
+	got, err := expandListPlaceholder("WHERE note <> 'why?' /* ? */ AND id IN (?) -- or ?", "sqlite3", 2)
+	if err != nil || got != "WHERE note <> 'why?' /* ? */ AND id IN (?, ?) -- or ?" {
+		t.Errorf("Expected literals and comments to be skipped, got %q, %v", got, err)
+	}
+	if _, err := expandListPlaceholder("WHERE note = 'a ?'", "sqlite3", 2); err == nil {
+		t.Error("Expected error for a placeholder only inside a literal")
+	}
+}
+
+func TestPreload_ChunksKeys(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// SQLite allows 999 parameters per statement: 1000 keys take two queries
+	users := make([]*RelTestUser, 1000)
+	for i := range users {
+		users[i] = &RelTestUser{ID: int64(i + 1)}
+	}
+	postColumns := []string{"id", "user_id", "title"}
+	mock.ExpectQuery(`WHERE user_id IN \(\?(, \?){998}\) ORDER BY id$`).WillReturnRows(sqlmock.NewRows(postColumns).AddRow(int64(10), int64(1), "first"))
+	mock.ExpectQuery(`WHERE user_id IN \(\?\) ORDER BY id$`).WithArgs(int64(1000)).WillReturnRows(sqlmock.NewRows(postColumns).AddRow(int64(11), int64(1000), "last"))
+
+	db := NewDB(sqlDB, "sqlite3", 5*time.Second)
+	if err := Preload(context.Background(), db, users, "Posts"); err != nil {
+		t.Fatalf("Preload failed: %v", err)
+	}
+	if len(users[0].Posts) != 1 || users[0].Posts[0].Title != "first" || len(users[999].Posts) != 1 || users[999].Posts[0].Title != "last" {
+		t.Errorf("Expected posts from both chunks, got %+v and %+v", users[0].Posts, users[999].Posts)
+	}
+	if len(users[1].Posts) != 0 {
+		t.Errorf("Expected no posts for user 2, got %+v", users[1].Posts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	for input, want := range map[string]string{"User": "user", "BlogPost": "blog_post", "UserID": "user_id", "HTTPRequest": "http_request"} {
+		if got := toSnakeCase(input); got != want {
+			t.Errorf("toSnakeCase(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if got := columnToFieldName("user_id"); got != "UserID" {
+		t.Errorf("columnToFieldName(user_id) = %q", got)
+	}
+}
+
+func TestPreload_SQLite(t *testing.T) {
+	logger := &testLogger{}
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER, title TEXT)",
+		"CREATE TABLE comments (id INTEGER PRIMARY KEY, post_id INTEGER, body TEXT)",
+		"CREATE TABLE roles (id INTEGER PRIMARY KEY, name TEXT)",
+		"CREATE TABLE user_roles (user_id INTEGER, role_id INTEGER)",
+		"INSERT INTO users (id, name) VALUES (1, 'Alice'), (2, 'Bob'), (3, 'Carol')",
+		"INSERT INTO posts (id, user_id, title) VALUES (10, 1, 'A1'), (11, 1, 'A2'), (12, 2, 'B1')",
+		"INSERT INTO comments (id, post_id, body) VALUES (100, 10, 'c1'), (101, 12, 'c2'), (102, 10, 'c3')",
+		"INSERT INTO roles (id, name) VALUES (1, 'admin'), (2, 'editor')",
+		"INSERT INTO user_roles (user_id, role_id) VALUES (1, 1), (1, 2), (2, 2)",
+	} {
+		if _, err := db.Exec(ctx, stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+
+	users, err := QueryAll[*RelTestUser](ctx, db, "SELECT id, name FROM users ORDER BY id")
+	if err != nil {
+		t.Fatalf("QueryAll failed: %v", err)
+	}
+
+	logger.debugs = nil
+	if err := Preload(ctx, db, users, "Posts.Comments", "Posts", "Roles"); err != nil {
+		t.Fatalf("Preload failed: %v", err)
+	}
+
+	queries := 0
+	for _, entry := range logger.debugs {
+		if entry.msg == "Querying all rows" {
+			queries++
+		}
+	}
+	if queries != 3 {
+		t.Errorf("Expected one query per relation level (3), got %d", queries)
+	}
+
+	alice, bob, carol := users[0], users[1], users[2]
+	if len(alice.Posts) != 2 || alice.Posts[0].Title != "A1" || len(alice.Posts[0].Comments) != 2 || len(alice.Posts[1].Comments) != 0 {
+		t.Errorf("Unexpected posts for Alice: %+v", alice.Posts)
+	}
+	if len(bob.Posts) != 1 || len(bob.Posts[0].Comments) != 1 || bob.Posts[0].Comments[0].Body != "c2" {
+		t.Errorf("Unexpected posts for Bob: %+v", bob.Posts)
+	}
+	if carol.Posts == nil || len(carol.Posts) != 0 || len(carol.Roles) != 0 {
+		t.Errorf("Expected empty relations for Carol, got %+v %+v", carol.Posts, carol.Roles)
+	}
+	if len(alice.Roles) != 2 || alice.Roles[1].Name != "editor" || len(bob.Roles) != 1 || bob.Roles[0].ID != 2 {
+		t.Errorf("Unexpected roles %+v %+v", alice.Roles, bob.Roles)
+	}
+
+	posts, err := QueryAll[*RelTestPost](ctx, db, "SELECT id, user_id, title FROM posts ORDER BY id")
+	if err != nil {
+		t.Fatalf("QueryAll failed: %v", err)
+	}
+	if err := Preload(ctx, db, posts, "Author"); err != nil {
+		t.Fatalf("Preload failed: %v", err)
+	}
+	if posts[0].Author == nil || posts[0].Author != posts[1].Author || posts[0].Author.Name != "Alice" || posts[2].Author.Name != "Bob" {
+		t.Errorf("Unexpected authors %+v %+v %+v", posts[0].Author, posts[1].Author, posts[2].Author)
+	}
+
+	if err := Preload(ctx, db, users, "Missing"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("Expected ErrFieldNotFound, got %v", err)
+	}
+}
+
+func TestPreload_NullableForeignKeys(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)",
+		"CREATE TABLE drafts (id INTEGER PRIMARY KEY, user_id INTEGER, editor_id INTEGER)",
+		"INSERT INTO users (id, name) VALUES (1, 'Alice'), (2, 'Bob')",
+		"INSERT INTO drafts (id, user_id, editor_id) VALUES (10, 1, 2), (11, NULL, NULL), (12, 2, 1)",
+	} {
+		if _, err := db.Exec(ctx, stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+
+	drafts, err := QueryAll[*RelTestDraft](ctx, db, "SELECT id, user_id, editor_id FROM drafts ORDER BY id")
+	if err != nil {
+		t.Fatalf("QueryAll failed: %v", err)
+	}
+	if err := Preload(ctx, db, drafts, "Author", "Editor"); err != nil {
+		t.Fatalf("Preload failed: %v", err)
+	}
+	if drafts[0].Author == nil || drafts[0].Author.Name != "Alice" || drafts[2].Author == nil || drafts[2].Author.Name != "Bob" {
+		t.Errorf("Expected authors loaded through *int64 keys, got %+v and %+v", drafts[0].Author, drafts[2].Author)
+	}
+	if drafts[0].Editor == nil || drafts[0].Editor.Name != "Bob" || drafts[2].Editor == nil || drafts[2].Editor.Name != "Alice" {
+		t.Errorf("Expected editors loaded through Null[int64] keys, got %+v and %+v", drafts[0].Editor, drafts[2].Editor)
+	}
+	if drafts[1].Author != nil || drafts[1].Editor != nil {
+		t.Errorf("Expected NULL keys to load nothing, got %+v and %+v", drafts[1].Author, drafts[1].Editor)
+	}
+}
+
+func TestPreload_EmbeddedRelation(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER, title TEXT)",
+		"INSERT INTO posts (id, user_id, title) VALUES (10, 1, 'A1')",
+	} {
+		if _, err := db.Exec(ctx, stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+
+	users := []*RelTestEmbeddingUser{{ID: 1}, {ID: 2}}
+	if err := Preload(ctx, db, users, "Posts"); err != nil {
+		t.Fatalf("Preload failed: %v", err)
+	}
+	if users[0].RelTestUserPosts == nil || len(users[0].Posts) != 1 || users[0].Posts[0].Title != "A1" {
+		t.Errorf("Expected posts on the embedded struct, got %+v", users[0].RelTestUserPosts)
+	}
+	if users[1].RelTestUserPosts == nil || len(users[1].Posts) != 0 {
+		t.Errorf("Expected an empty relation for user 2, got %+v", users[1].RelTestUserPosts)
+	}
+}
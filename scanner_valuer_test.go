@@ -0,0 +1,226 @@
+package typedb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ScannerTestTags is a comma-separated list stored in a TEXT column
+type ScannerTestTags []string
+
+func (t *ScannerTestTags) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+	case string:
+		*t = strings.Split(v, ",")
+	default:
+		return fmt.Errorf("unsupported type %T", src)
+	}
+	return nil
+}
+
+func (t ScannerTestTags) Value() (driver.Value, error) {
+	return strings.Join(t, ","), nil
+}
+
+// ScannerTestFailing always fails to produce a value
+type ScannerTestFailing struct{}
+
+func (ScannerTestFailing) Value() (driver.Value, error) {
+	return nil, errors.New("boom")
+}
+
+// ScannerTestUser is a test model for sql.Scanner / driver.Valuer tests
+type ScannerTestUser struct {
+	Model
+	Nickname sql.NullString  `db:"nickname"`
+	Age      *sql.NullInt64  `db:"age"`
+	Name     *string         `db:"name"`
+	Tags     ScannerTestTags `db:"tags"`
+	Email    string          `db:"email"`
+	ID       int64           `db:"id" load:"primary"`
+}
+
+func (u *ScannerTestUser) TableName() string {
+	return "users"
+}
+
+func (u *ScannerTestUser) QueryByID() string {
+	return "SELECT id, name, email, nickname, age, tags FROM users WHERE id = ?"
+}
+
+func TestDeserialize_NullResetsFields(t *testing.T) {
+	name := "stale"
+	user := &ScannerTestUser{
+		Name:     &name,
+		Email:    "stale@example.com",
+		Nickname: sql.NullString{String: "stale", Valid: true},
+		Age:      &sql.NullInt64{Int64: 9, Valid: true},
+		Tags:     ScannerTestTags{"stale"},
+	}
+
+	row := map[string]any{"id": int64(1), "name": nil, "email": nil, "nickname": nil, "age": nil, "tags": nil}
+	if err := deserialize(row, user); err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+
+	if user.Name != nil {
+		t.Errorf("Expected Name = nil, got %q", *user.Name)
+	}
+	if user.Email != "" {
+		t.Errorf("Expected Email to be reset, got %q", user.Email)
+	}
+	if user.Nickname.Valid {
+		t.Errorf("Expected Nickname to be invalid, got %+v", user.Nickname)
+	}
+	if user.Age != nil {
+		t.Errorf("Expected Age = nil, got %+v", user.Age)
+	}
+	if user.Tags != nil {
+		t.Errorf("Expected Tags = nil, got %v", user.Tags)
+	}
+}
+
+func TestDeserialize_Scanner(t *testing.T) {
+	row := map[string]any{"id": int64(1), "nickname": "al", "age": int64(42), "tags": "a,b"}
+
+	user, err := deserializeForType[*ScannerTestUser](row)
+	if err != nil {
+		t.Fatalf("deserializeForType failed: %v", err)
+	}
+
+	if user.Nickname != (sql.NullString{String: "al", Valid: true}) {
+		t.Errorf("Expected Nickname = al, got %+v", user.Nickname)
+	}
+	if user.Age == nil || *user.Age != (sql.NullInt64{Int64: 42, Valid: true}) {
+		t.Errorf("Expected Age = 42, got %+v", user.Age)
+	}
+	if !reflect.DeepEqual(user.Tags, ScannerTestTags{"a", "b"}) {
+		t.Errorf("Expected Tags = [a b], got %v", user.Tags)
+	}
+
+	_, err = deserializeForType[*ScannerTestUser](map[string]any{"tags": int64(1)})
+	if err == nil || !strings.Contains(err.Error(), "field tags") {
+		t.Errorf("Expected Scan error naming the field, got %v", err)
+	}
+}
+
+func TestIsZeroOrNil_Valuer(t *testing.T) {
+	tests := []struct {
+		value any
+		name  string
+		want  bool
+	}{
+		{name: "invalid NullString", value: sql.NullString{String: "x"}, want: true},
+		{name: "valid empty NullString", value: sql.NullString{Valid: true}, want: false},
+		{name: "valid zero NullInt64", value: sql.NullInt64{Valid: true}, want: false},
+		{name: "nil pointer to Null type", value: (*sql.NullInt64)(nil), want: true},
+		{name: "nil Valuer slice", value: ScannerTestTags(nil), want: true},
+		{name: "Valuer slice", value: ScannerTestTags{"a"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isZeroOrNil(reflect.ValueOf(tt.value)); got != tt.want {
+				t.Errorf("isZeroOrNil(%#v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSerializeModelFields_Valuer(t *testing.T) {
+	user := &ScannerTestUser{
+		Email:    "a@example.com",
+		Nickname: sql.NullString{String: "ignored"},
+		Age:      &sql.NullInt64{Int64: 0, Valid: true},
+		Tags:     ScannerTestTags{"a", "b"},
+	}
+
+	columns, values, _, err := serializeModelFields(user, "ID")
+	if err != nil {
+		t.Fatalf("serializeModelFields failed: %v", err)
+	}
+
+	wantColumns := []string{"age", "tags", "email"}
+	wantValues := []any{int64(0), "a,b", "a@example.com"}
+	if !reflect.DeepEqual(columns, wantColumns) {
+		t.Errorf("Expected columns %v, got %v", wantColumns, columns)
+	}
+	if !reflect.DeepEqual(values, wantValues) {
+		t.Errorf("Expected values %v, got %v", wantValues, values)
+	}
+}
+
+func TestSerializeModelFields_ValuerError(t *testing.T) {
+	type failingModel struct {
+		Model
+		Bad ScannerTestFailing `db:"bad"`
+		ID  int64              `db:"id" load:"primary"`
+	}
+
+	_, _, _, err := serializeModelFields(&failingModel{}, "ID")
+	if err == nil || !strings.Contains(err.Error(), "field Bad") {
+		t.Errorf("Expected Value error naming the field, got %v", err)
+	}
+
+	_, _, _, _, err = serializeModelFieldsForUpdate(&failingModel{}, "ID", "sqlite3", nil)
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Expected Value error from update serialization, got %v", err)
+	}
+}
+
+func TestScannerValuer_SQLiteRoundTrip(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, email TEXT, nickname TEXT, age INTEGER, tags TEXT)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	user := &ScannerTestUser{
+		Email:    "a@example.com",
+		Nickname: sql.NullString{String: "al", Valid: true},
+		Tags:     ScannerTestTags{"x", "y"},
+	}
+	if err := Insert(ctx, db, user); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	loaded := &ScannerTestUser{ID: user.ID, Age: &sql.NullInt64{Int64: 7, Valid: true}}
+	if err := Load(ctx, db, loaded); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Nickname.String != "al" || !loaded.Nickname.Valid {
+		t.Errorf("Expected Nickname = al, got %+v", loaded.Nickname)
+	}
+	if loaded.Age != nil {
+		t.Errorf("Expected NULL age to reset pointer, got %+v", loaded.Age)
+	}
+	if !reflect.DeepEqual(loaded.Tags, ScannerTestTags{"x", "y"}) {
+		t.Errorf("Expected Tags = [x y], got %v", loaded.Tags)
+	}
+
+	loaded.Age = &sql.NullInt64{Int64: 30, Valid: true}
+	if err := Update(ctx, db, loaded); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	var age int64
+	if err := db.GetInto(ctx, "SELECT age FROM users WHERE id = ?", []any{user.ID}, &age); err != nil {
+		t.Fatalf("GetInto failed: %v", err)
+	}
+	if age != 30 {
+		t.Errorf("Expected age = 30, got %d", age)
+	}
+}
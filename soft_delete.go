@@ -0,0 +1,244 @@
+package typedb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Values of the dbDelete tag.
+const (
+	// softDeleteTimestamp marks a nullable timestamp column set to the database's current
+	// timestamp on delete; NULL means the row is not deleted.
+	softDeleteTimestamp = "soft-timestamp"
+	// softDeleteFlag marks a boolean column set to true on delete.
+	softDeleteFlag = "soft-flag"
+)
+
+// softDeleteColumn is the column a model is soft-deleted by.
+type softDeleteColumn struct {
+	name string
+	mode string
+}
+
+// findSoftDeleteColumn returns the column of the model's dbDelete field, if it has one.
+func findSoftDeleteColumn(model any) (softDeleteColumn, bool) {
+	t := getModelType(model)
+	for _, mode := range []string{softDeleteTimestamp, softDeleteFlag} {
+		if field, found := findFieldByTagRecursive(t, "dbDelete", mode); found {
+			return softDeleteColumn{name: field.Tag.Get("db"), mode: mode}, true
+		}
+	}
+	return softDeleteColumn{}, false
+}
+
+// notDeletedCondition returns the predicate matching rows that are not soft-deleted.
+func (c softDeleteColumn) notDeletedCondition(driverName string) string {
+	column := quoteIdentifier(driverName, c.name)
+	if c.mode == softDeleteFlag {
+		return fmt.Sprintf("%s = %s", column, booleanLiteral(driverName, false))
+	}
+	return column + " IS NULL"
+}
+
+// deletedCondition returns the predicate matching soft-deleted rows.
+func (c softDeleteColumn) deletedCondition(driverName string) string {
+	column := quoteIdentifier(driverName, c.name)
+	if c.mode == softDeleteFlag {
+		return fmt.Sprintf("%s = %s", column, booleanLiteral(driverName, true))
+	}
+	return column + " IS NOT NULL"
+}
+
+// deletedValue returns the SQL expression written to the column on delete.
+func (c softDeleteColumn) deletedValue(driverName string) string {
+	if c.mode == softDeleteFlag {
+		return booleanLiteral(driverName, true)
+	}
+	return getTimestampFunction(driverName)
+}
+
+// restoredValue returns the SQL expression written to the column on restore.
+func (c softDeleteColumn) restoredValue(driverName string) string {
+	if c.mode == softDeleteFlag {
+		return booleanLiteral(driverName, false)
+	}
+	return "NULL"
+}
+
+// booleanLiteral returns the database-specific literal for a boolean value.
+// SQL Server and Oracle have no boolean literals and store flags as BIT or NUMBER(1).
+func booleanLiteral(driverName string, value bool) string {
+	switch strings.ToLower(driverName) {
+	case "sqlserver", "mssql", "oracle":
+		if value {
+			return "1"
+		}
+		return "0"
+	default:
+		if value {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+}
+
+// NotDeletedCondition returns the predicate matching rows of T that are not soft-deleted, for use in
+// QueryBy* methods and other hand-written queries. typedb does not rewrite SELECT statements, so
+// queries must filter soft-deleted rows themselves.
+// The column is quoted for the driver and not qualified with a table name.
+// Returns "1 = 1" if T has no dbDelete field.
+//
+// Example:
+//
+//	func (u *User) QueryByID() string {
+//	    return "SELECT id, name, deleted_at FROM users WHERE id = $1 AND " +
+//	        typedb.NotDeletedCondition[*User]("postgres")
+//	}
+//	// SELECT id, name, deleted_at FROM users WHERE id = $1 AND "deleted_at" IS NULL
+func NotDeletedCondition[T ModelInterface](driverName string) string {
+	var model T
+	column, ok := findSoftDeleteColumn(model)
+	if !ok {
+		return "1 = 1"
+	}
+	return column.notDeletedCondition(driverName)
+}
+
+type hardDeleteKey struct{}
+
+// WithHardDelete makes the delete functions physically delete rows of models with a dbDelete field
+// instead of soft-deleting them.
+//
+// Example:
+//
+//	err := typedb.Delete(typedb.WithHardDelete(ctx), db, user)
+//	// Generates: DELETE FROM users WHERE id = $1
+func WithHardDelete(ctx context.Context) context.Context {
+	return context.WithValue(ctx, hardDeleteKey{}, true)
+}
+
+// isHardDelete reports whether WithHardDelete was set on the context.
+func isHardDelete(ctx context.Context) bool {
+	hard, _ := ctx.Value(hardDeleteKey{}).(bool)
+	return hard
+}
+
+type updateSoftDeletedKey struct{}
+
+// WithUpdateSoftDeleted allows Update to modify rows that are soft-deleted.
+//
+// Example:
+//
+//	err := typedb.Update(typedb.WithUpdateSoftDeleted(ctx), db, user)
+func WithUpdateSoftDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, updateSoftDeletedKey{}, true)
+}
+
+// isUpdateSoftDeleted reports whether WithUpdateSoftDeleted was set on the context.
+func isUpdateSoftDeleted(ctx context.Context) bool {
+	allowed, _ := ctx.Value(updateSoftDeletedKey{}).(bool)
+	return allowed
+}
+
+// Restore undoes the soft delete of a model by its load:"primary" field, setting its
+// dbDelete:"soft-timestamp" column to NULL or its dbDelete:"soft-flag" column to false.
+// Returns ErrNotFound if no soft-deleted row matched. The model itself is not modified;
+// load it again to read the restored row.
+//
+// Example:
+//
+//	err := typedb.Restore(ctx, db, &User{ID: 123})
+//	// Generates: UPDATE users SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL
+func Restore[T ModelInterface](ctx context.Context, exec Executor, model T) error {
+	tableName, primaryField, _, err := validateDeleteModel(model)
+	if err != nil {
+		return err
+	}
+	column, ok := findSoftDeleteColumn(model)
+	if !ok {
+		return fmt.Errorf("typedb: Restore requires a field with dbDelete:%q or dbDelete:%q tag", softDeleteTimestamp, softDeleteFlag)
+	}
+
+	key, err := deleteKeyForField(exec, model, primaryField)
+	if err != nil {
+		return err
+	}
+
+	driverName := getDriverName(exec)
+	query := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s AND %s",
+		quoteIdentifier(driverName, tableName),
+		quoteIdentifier(driverName, column.name),
+		column.restoredValue(driverName),
+		quoteIdentifier(driverName, key.column),
+		generatePlaceholder(driverName, 1),
+		column.deletedCondition(driverName))
+
+	if key.nolog {
+		ctx = WithMaskIndices(ctx, []int{0})
+	}
+	result, err := exec.Exec(ctx, query, key.value)
+	if err != nil {
+		return fmt.Errorf("typedb: Restore failed: %w", err)
+	}
+	if result == nil {
+		return fmt.Errorf("typedb: Restore returned nil result")
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("typedb: Restore failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// validateSoftDeleteFields checks dbDelete tags: at most one per model, with a known value,
+// on a field with a db column. soft-flag fields must be booleans.
+func validateSoftDeleteFields(t reflect.Type) []string {
+	var errors []string
+	var tagged []string
+
+	var check func(reflect.Type)
+	check = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					check(embeddedType)
+					continue
+				}
+			}
+			mode, ok := field.Tag.Lookup("dbDelete")
+			if !ok {
+				continue
+			}
+			tagged = append(tagged, field.Name)
+
+			dbTag := field.Tag.Get("db")
+			switch {
+			case mode != softDeleteTimestamp && mode != softDeleteFlag:
+				errors = append(errors, fmt.Sprintf("field %s: dbDelete must be %q or %q, got %q", field.Name, softDeleteTimestamp, softDeleteFlag, mode))
+			case dbTag == "" || dbTag == "-" || strings.Contains(dbTag, "."):
+				errors = append(errors, fmt.Sprintf("field %s: dbDelete requires a db column", field.Name))
+			case mode == softDeleteFlag && validateValueType(field.Type).Kind() != reflect.Bool:
+				errors = append(errors, fmt.Sprintf("field %s: dbDelete:%q requires a bool field, got %v", field.Name, softDeleteFlag, field.Type))
+			}
+		}
+	}
+
+	check(t)
+	if len(tagged) > 1 {
+		errors = append(errors, fmt.Sprintf("only one field may have a dbDelete tag, found %s", strings.Join(tagged, ", ")))
+	}
+	return errors
+}
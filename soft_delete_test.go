@@ -0,0 +1,256 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// SoftDeleteTestUser is a test model soft-deleted by timestamp
+type SoftDeleteTestUser struct {
+	Model
+	DeletedAt *time.Time `db:"deleted_at" dbDelete:"soft-timestamp"`
+	Name      string     `db:"name"`
+	ID        int64      `db:"id" load:"primary"`
+}
+
+func (u *SoftDeleteTestUser) TableName() string {
+	return "users"
+}
+
+func (u *SoftDeleteTestUser) QueryByID() string {
+	return "SELECT id, name, deleted_at FROM users WHERE id = ? AND " + NotDeletedCondition[*SoftDeleteTestUser]("sqlite3")
+}
+
+// SoftDeleteTestPost is a test model soft-deleted by flag
+type SoftDeleteTestPost struct {
+	Model
+	Title   string `db:"title"`
+	ID      int64  `db:"id" load:"primary"`
+	Deleted bool   `db:"deleted" dbDelete:"soft-flag"`
+}
+
+func (p *SoftDeleteTestPost) TableName() string {
+	return "posts"
+}
+
+func (p *SoftDeleteTestPost) QueryByID() string {
+	return "SELECT id, title, deleted FROM posts WHERE id = ?"
+}
+
+// SoftDeleteTestInvalid is a test model with invalid dbDelete tags
+type SoftDeleteTestInvalid struct {
+	Model
+	RemovedAt *time.Time `db:"removed_at" dbDelete:"soft-timestamp"`
+	Archived  string     `db:"archived" dbDelete:"soft-flag"`
+	Hidden    bool       `db:"-" dbDelete:"soft-flag"`
+	Gone      bool       `db:"gone" dbDelete:"hard"`
+	ID        int64      `db:"id" load:"primary"`
+}
+
+func (m *SoftDeleteTestInvalid) QueryByID() string {
+	return "SELECT id FROM invalid WHERE id = ?"
+}
+
+func TestSoftDelete_SQLite(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, deleted_at DATETIME)",
+		"INSERT INTO users (id, name) VALUES (1, 'Alice'), (2, 'Bob'), (3, 'Carol')",
+	} {
+		if _, err := db.Exec(ctx, stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+
+	if err := Delete(ctx, db, &SoftDeleteTestUser{ID: 1}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := Delete(ctx, db, &SoftDeleteTestUser{ID: 1}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound deleting a soft-deleted row, got %v", err)
+	}
+	if err := Load(ctx, db, &SoftDeleteTestUser{ID: 1}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected QueryByID to skip the soft-deleted row, got %v", err)
+	}
+	row, err := db.QueryRowMap(ctx, "SELECT COUNT(*) AS n FROM users WHERE deleted_at IS NOT NULL")
+	if err != nil || row["n"] != int64(1) {
+		t.Errorf("Expected the row to be kept with deleted_at set, got %v, %v", row, err)
+	}
+
+	if err := Update(ctx, db, &SoftDeleteTestUser{ID: 1, Name: "Changed"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected Update to refuse the soft-deleted row, got %v", err)
+	}
+	if err := Update(WithUpdateSoftDeleted(ctx), db, &SoftDeleteTestUser{ID: 1, Name: "Alicia"}); err != nil {
+		t.Errorf("Expected WithUpdateSoftDeleted to allow the update, got %v", err)
+	}
+	if err := Update(ctx, db, &SoftDeleteTestUser{ID: 2, Name: "Robert"}); err != nil {
+		t.Errorf("Update failed: %v", err)
+	}
+
+	if err := Restore(ctx, db, &SoftDeleteTestUser{ID: 1}); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if err := Restore(ctx, db, &SoftDeleteTestUser{ID: 1}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound restoring a row that is not deleted, got %v", err)
+	}
+	user := &SoftDeleteTestUser{ID: 1}
+	if err := Load(ctx, db, user); err != nil || user.Name != "Alicia" || user.DeletedAt != nil {
+		t.Errorf("Expected the restored row, got %+v, %v", user, err)
+	}
+
+	if err := DeleteMany(ctx, db, []*SoftDeleteTestUser{{ID: 2}, {ID: 3}}); err != nil {
+		t.Errorf("DeleteMany failed: %v", err)
+	}
+	if err := Delete(WithHardDelete(ctx), db, &SoftDeleteTestUser{ID: 3}); err != nil {
+		t.Errorf("Hard delete failed: %v", err)
+	}
+	row, err = db.QueryRowMap(ctx, "SELECT COUNT(*) AS n, COUNT(deleted_at) AS deleted FROM users")
+	if err != nil || row["n"] != int64(2) || row["deleted"] != int64(1) {
+		t.Errorf("Expected users 1 and 2 to remain with 2 soft-deleted, got %v, %v", row, err)
+	}
+}
+
+func TestSoftDelete_QueriesPerDriver(t *testing.T) {
+	tests := []struct {
+		driver  string
+		delete  string
+		restore string
+		update  string
+		exists  string
+	}{
+		{
+			driver:  "postgres",
+			delete:  `UPDATE "posts" SET "deleted" = TRUE WHERE "id" = $1 AND "deleted" = FALSE`,
+			restore: `UPDATE "posts" SET "deleted" = FALSE WHERE "id" = $1 AND "deleted" = TRUE`,
+			update:  `UPDATE "posts" SET "title" = $1 WHERE "id" = $2 AND "deleted" = FALSE`,
+			exists:  `SELECT 1 AS found FROM "posts" WHERE "id" = $1 AND "deleted" = FALSE`,
+		},
+		{
+			driver:  "mysql",
+			delete:  "UPDATE `posts` SET `deleted` = TRUE WHERE `id` = ? AND `deleted` = FALSE",
+			restore: "UPDATE `posts` SET `deleted` = FALSE WHERE `id` = ? AND `deleted` = TRUE",
+			update:  "UPDATE `posts` SET `title` = ? WHERE `id` = ? AND `deleted` = FALSE",
+			exists:  "SELECT 1 AS found FROM `posts` WHERE `id` = ? AND `deleted` = FALSE",
+		},
+		{
+			driver:  "sqlserver",
+			delete:  "UPDATE [posts] SET [deleted] = 1 WHERE [id] = @p1 AND [deleted] = 0",
+			restore: "UPDATE [posts] SET [deleted] = 0 WHERE [id] = @p1 AND [deleted] = 1",
+			update:  "UPDATE [posts] SET [title] = @p1 WHERE [id] = @p2 AND [deleted] = 0",
+			exists:  "SELECT 1 AS found FROM [posts] WHERE [id] = @p1 AND [deleted] = 0",
+		},
+		{
+			driver:  "oracle",
+			delete:  `UPDATE "POSTS" SET "DELETED" = 1 WHERE "ID" = :1 AND "DELETED" = 0`,
+			restore: `UPDATE "POSTS" SET "DELETED" = 0 WHERE "ID" = :1 AND "DELETED" = 1`,
+			update:  `UPDATE "POSTS" SET "TITLE" = :1 WHERE "ID" = :2 AND "DELETED" = 0`,
+			exists:  `SELECT 1 AS found FROM "POSTS" WHERE "ID" = :1 AND "DELETED" = 0`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock: %v", err)
+			}
+			defer sqlDB.Close()
+
+			db := NewDB(sqlDB, tt.driver, 5*time.Second)
+			mock.ExpectExec(regexp.QuoteMeta(tt.delete)).WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(tt.restore)).WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(regexp.QuoteMeta(tt.update)).WithArgs("Hello", int64(7)).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectQuery(regexp.QuoteMeta(tt.exists)).WithArgs(int64(7)).WillReturnRows(sqlmock.NewRows([]string{"found"}))
+
+			ctx := context.Background()
+			if err := Delete(ctx, db, &SoftDeleteTestPost{ID: 7}); err != nil {
+				t.Errorf("Delete failed: %v", err)
+			}
+			if err := Restore(ctx, db, &SoftDeleteTestPost{ID: 7}); err != nil {
+				t.Errorf("Restore failed: %v", err)
+			}
+			if err := Update(ctx, db, &SoftDeleteTestPost{ID: 7, Title: "Hello"}); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Expected ErrNotFound for a soft-deleted row, got %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdate_MySQLUnchangedRow(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// MySQL reports 0 affected rows when the values are unchanged; the row still exists
+	db := NewDB(sqlDB, "mysql", 5*time.Second)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `posts` SET `title` = ? WHERE `id` = ? AND `deleted` = FALSE")).WithArgs("Hello", int64(7)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 AS found FROM `posts` WHERE `id` = ? AND `deleted` = FALSE")).WithArgs(int64(7)).WillReturnRows(sqlmock.NewRows([]string{"found"}).AddRow(int64(1)))
+
+	if err := Update(context.Background(), db, &SoftDeleteTestPost{ID: 7, Title: "Hello"}); err != nil {
+		t.Errorf("Expected an unchanged existing row to update without error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestNotDeletedCondition(t *testing.T) {
+	tests := []struct {
+		got  string
+		want string
+	}{
+		{got: NotDeletedCondition[*SoftDeleteTestUser]("postgres"), want: `"deleted_at" IS NULL`},
+		{got: NotDeletedCondition[*SoftDeleteTestUser]("mysql"), want: "`deleted_at` IS NULL"},
+		{got: NotDeletedCondition[*SoftDeleteTestPost]("sqlite3"), want: `"deleted" = FALSE`},
+		{got: NotDeletedCondition[*SoftDeleteTestPost]("sqlserver"), want: "[deleted] = 0"},
+		{got: NotDeletedCondition[*DeleteTestUser]("postgres"), want: "1 = 1"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, tt.got)
+		}
+	}
+}
+
+func TestRestore_RequiresSoftDelete(t *testing.T) {
+	err := Restore(context.Background(), &MockExecutor{}, &DeleteTestUser{ID: 1})
+	if err == nil || !strings.Contains(err.Error(), "dbDelete") {
+		t.Errorf("Expected dbDelete error, got %v", err)
+	}
+}
+
+func TestValidateModel_SoftDeleteTags(t *testing.T) {
+	if err := ValidateModel(&SoftDeleteTestUser{}); err != nil {
+		t.Errorf("Expected valid tags, got %v", err)
+	}
+
+	err := ValidateModel(&SoftDeleteTestInvalid{})
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+	for _, want := range []string{
+		`field Archived: dbDelete:"soft-flag" requires a bool field, got string`,
+		"field Hidden: dbDelete requires a db column",
+		`field Gone: dbDelete must be "soft-timestamp" or "soft-flag", got "hard"`,
+		"only one field may have a dbDelete tag, found RemovedAt, Archived, Hidden, Gone",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error containing %q, got %v", want, err)
+		}
+	}
+}
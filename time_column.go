@@ -0,0 +1,512 @@
+package typedb
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Supported timeFormat tag values besides custom layouts.
+const (
+	timeFormatUnix      = "unix"      // Seconds since the Unix epoch
+	timeFormatUnixMilli = "unixmilli" // Milliseconds since the Unix epoch
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// timeSettings holds DB-level time handling configured with WithTimeLocation,
+// WithTimeNormalization and WithTimePrecision.
+type timeSettings struct {
+	location  *time.Location // Assumed for timestamps without zone information; nil means UTC
+	precision time.Duration  // Truncation applied to times on write; 0 disables truncation
+	normalize bool           // Convert times to location on read and write
+}
+
+// loc returns the configured location, defaulting to UTC.
+func (s timeSettings) loc() *time.Location {
+	if s.location == nil {
+		return time.UTC
+	}
+	return s.location
+}
+
+// getExecutorTimeSettings extracts time settings from an Executor.
+func getExecutorTimeSettings(exec Executor) timeSettings {
+	switch e := exec.(type) {
+	case *DB:
+		return e.times
+	case *Tx:
+		return e.times
+	default:
+		return timeSettings{}
+	}
+}
+
+// timeFormatColumnsCache maps a struct type to the timeFormat tag of each db column that has one.
+var timeFormatColumnsCache sync.Map // map[reflect.Type]map[string]string
+
+// timeFormatColumns returns the timeFormat tags on a struct type keyed by db tag, including embedded structs.
+func timeFormatColumns(t reflect.Type) map[string]string {
+	if cached, ok := timeFormatColumnsCache.Load(t); ok {
+		return cached.(map[string]string)
+	}
+
+	columns := make(map[string]string)
+	var collect func(reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					collect(embeddedType)
+					continue
+				}
+			}
+			dbTag := field.Tag.Get("db")
+			format := field.Tag.Get("timeFormat")
+			if dbTag == "" || dbTag == "-" || format == "" {
+				continue
+			}
+			columns[dbTag] = format
+		}
+	}
+	collect(t)
+
+	timeFormatColumnsCache.Store(t, columns)
+	return columns
+}
+
+// decodeTimeColumn deserializes a non-NULL value into a time.Time or time.Duration field
+// (or a pointer to one), applying the field's timeFormat and the DB time settings.
+// Returns errNotMyType for other fields and for values left to the generic conversions.
+func decodeTimeColumn(fieldValuePtr reflect.Value, value any, format string, settings timeSettings) error {
+	if value == nil {
+		return errNotMyType
+	}
+
+	fieldElem := fieldValuePtr.Elem()
+	baseType := fieldElem.Type()
+	isPtr := baseType.Kind() == reflect.Ptr
+	if isPtr {
+		baseType = baseType.Elem()
+	}
+
+	var result reflect.Value
+	switch baseType {
+	case timeType:
+		t, err := decodeTime(value, format, settings)
+		if err != nil {
+			return err
+		}
+		result = reflect.ValueOf(t)
+	case durationType:
+		d, err := decodeDuration(value)
+		if err != nil {
+			return err
+		}
+		result = reflect.ValueOf(d)
+	default:
+		return errNotMyType
+	}
+
+	if isPtr {
+		ptr := reflect.New(baseType)
+		ptr.Elem().Set(result)
+		result = ptr
+	}
+	fieldElem.Set(result)
+	return nil
+}
+
+// decodeTime converts a column value to time.Time according to a timeFormat tag value.
+func decodeTime(value any, format string, settings timeSettings) (time.Time, error) {
+	loc := settings.loc()
+
+	var t time.Time
+	switch format {
+	case "":
+		switch v := value.(type) {
+		case time.Time:
+			t = v
+		case string:
+			parsed, err := parseTimeInLocation(v, loc)
+			if err != nil {
+				return time.Time{}, err
+			}
+			t = parsed
+		case []byte:
+			parsed, err := parseTimeInLocation(string(v), loc)
+			if err != nil {
+				return time.Time{}, err
+			}
+			t = parsed
+		default:
+			return time.Time{}, errNotMyType
+		}
+	case timeFormatUnix, timeFormatUnixMilli:
+		if v, ok := value.(time.Time); ok {
+			t = v
+			break
+		}
+		n, err := epochValue(value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if format == timeFormatUnix {
+			t = time.Unix(n, 0).In(loc)
+		} else {
+			t = time.UnixMilli(n).In(loc)
+		}
+	default:
+		var s string
+		switch v := value.(type) {
+		case time.Time:
+			t = v
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return time.Time{}, fmt.Errorf("cannot deserialize %T with time layout %q", value, format)
+		}
+		if _, native := value.(time.Time); !native {
+			parsed, err := time.ParseInLocation(format, s, loc)
+			if err != nil {
+				return time.Time{}, err
+			}
+			t = parsed
+		}
+	}
+
+	if settings.normalize {
+		t = t.In(loc)
+	}
+	return t, nil
+}
+
+// epochValue converts an integer, float or numeric string column value to an int64 epoch count.
+func epochValue(value any) (int64, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	case []byte:
+		return strconv.ParseInt(strings.TrimSpace(string(v)), 10, 64)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("epoch value %d overflows int64", rv.Uint())
+		}
+		return int64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return int64(rv.Float()), nil
+	default:
+		return 0, fmt.Errorf("cannot deserialize %T to an epoch timestamp", value)
+	}
+}
+
+// decodeDuration converts interval text to time.Duration. Other values (e.g., integer nanoseconds)
+// return errNotMyType and use the generic numeric conversions.
+func decodeDuration(value any) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		return parseInterval(v)
+	case []byte:
+		return parseInterval(string(v))
+	default:
+		return 0, errNotMyType
+	}
+}
+
+// parseInterval parses a PostgreSQL interval in the postgres ("1 day 02:03:04.5"),
+// postgres_verbose ("@ 1 day 2 hours ago") or iso_8601 ("P1DT2H") output style,
+// or a Go duration string ("1h30m"). Intervals with year or month components have no fixed
+// length and are rejected.
+func parseInterval(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("typedb: invalid interval %q", s)
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	if strings.HasPrefix(s, "P") || strings.HasPrefix(s, "-P") {
+		return parseISOInterval(s)
+	}
+
+	fields := strings.Fields(s)
+	if fields[0] == "@" {
+		fields = fields[1:]
+	}
+	ago := false
+	if len(fields) > 0 && fields[len(fields)-1] == "ago" {
+		ago = true
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("typedb: invalid interval %q", s)
+	}
+
+	var total time.Duration
+	for i := 0; i < len(fields); i++ {
+		if strings.Contains(fields[i], ":") {
+			d, err := parseIntervalClock(fields[i])
+			if err != nil {
+				return 0, fmt.Errorf("typedb: invalid interval %q: %w", s, err)
+			}
+			total += d
+			continue
+		}
+		if i+1 >= len(fields) {
+			return 0, fmt.Errorf("typedb: invalid interval %q: missing unit after %q", s, fields[i])
+		}
+		unit, err := intervalUnit(fields[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("typedb: interval %q: %w", s, err)
+		}
+		d, err := scaleInterval(fields[i], unit)
+		if err != nil {
+			return 0, fmt.Errorf("typedb: invalid interval %q: %w", s, err)
+		}
+		total += d
+		i++
+	}
+
+	if ago {
+		total = -total
+	}
+	return total, nil
+}
+
+// intervalUnit returns the length of a PostgreSQL interval unit.
+func intervalUnit(unit string) (time.Duration, error) {
+	switch strings.TrimSuffix(strings.ToLower(unit), ",") {
+	case "week", "weeks":
+		return 7 * 24 * time.Hour, nil
+	case "day", "days":
+		return 24 * time.Hour, nil
+	case "hour", "hours", "hr", "hrs":
+		return time.Hour, nil
+	case "minute", "minutes", "min", "mins":
+		return time.Minute, nil
+	case "second", "seconds", "sec", "secs":
+		return time.Second, nil
+	case "millisecond", "milliseconds", "msec", "msecs", "ms":
+		return time.Millisecond, nil
+	case "microsecond", "microseconds", "usec", "usecs", "us":
+		return time.Microsecond, nil
+	case "year", "years", "yr", "yrs", "mon", "mons", "month", "months":
+		return 0, fmt.Errorf("%s component has no fixed duration", unit)
+	default:
+		return 0, fmt.Errorf("unknown unit %q", unit)
+	}
+}
+
+// scaleInterval multiplies a decimal count (e.g., "-1.5") by a unit without float rounding
+// for up to nine fractional digits.
+func scaleInterval(count string, unit time.Duration) (time.Duration, error) {
+	negative := strings.HasPrefix(count, "-")
+	count = strings.TrimLeft(count, "+-")
+
+	whole, frac, _ := strings.Cut(count, ".")
+	n, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	d := time.Duration(n) * unit
+	if frac != "" {
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		f, err := strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(f) * unit / 1e9
+	}
+
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+// parseIntervalClock parses the [-]H:MM:SS[.ffffff] part of an interval.
+func parseIntervalClock(s string) (time.Duration, error) {
+	negative := strings.HasPrefix(s, "-")
+	parts := strings.Split(strings.TrimLeft(s, "+-"), ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+
+	hours, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	minutes, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	if len(parts) == 3 {
+		seconds, err := scaleInterval(parts[2], time.Second)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time %q", s)
+		}
+		d += seconds
+	}
+
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+// parseISOInterval parses an ISO 8601 duration such as "P1DT2H3M4.5S" or "-PT5M".
+func parseISOInterval(s string) (time.Duration, error) {
+	negative := strings.HasPrefix(s, "-")
+	rest := strings.TrimPrefix(strings.TrimPrefix(s, "-"), "P")
+	if rest == "" {
+		return 0, fmt.Errorf("typedb: invalid interval %q", s)
+	}
+
+	var total time.Duration
+	inTime := false
+	for rest != "" {
+		if rest[0] == 'T' {
+			inTime = true
+			rest = rest[1:]
+			continue
+		}
+		end := strings.IndexAny(rest, "YMWDHS")
+		if end <= 0 {
+			return 0, fmt.Errorf("typedb: invalid interval %q", s)
+		}
+		count, designator := rest[:end], rest[end]
+		rest = rest[end+1:]
+
+		var unit time.Duration
+		switch {
+		case designator == 'Y' || (designator == 'M' && !inTime):
+			return 0, fmt.Errorf("typedb: interval %q: year or month component has no fixed duration", s)
+		case designator == 'W' && !inTime:
+			unit = 7 * 24 * time.Hour
+		case designator == 'D' && !inTime:
+			unit = 24 * time.Hour
+		case designator == 'H' && inTime:
+			unit = time.Hour
+		case designator == 'M' && inTime:
+			unit = time.Minute
+		case designator == 'S' && inTime:
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("typedb: invalid interval %q", s)
+		}
+		d, err := scaleInterval(count, unit)
+		if err != nil {
+			return 0, fmt.Errorf("typedb: invalid interval %q: %w", s, err)
+		}
+		total += d
+	}
+
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+// encodeTimeColumn serializes a time.Time (or non-nil *time.Time) field, applying the DB time
+// settings and the field's timeFormat tag. ok is false for other fields.
+func encodeTimeColumn(field reflect.StructField, v reflect.Value, settings timeSettings) (value any, ok bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Type() != timeType {
+		return nil, false
+	}
+
+	t, _ := v.Interface().(time.Time)
+	if settings.precision > 0 {
+		t = t.Truncate(settings.precision)
+	}
+	if settings.normalize {
+		t = t.In(settings.loc())
+	}
+
+	switch format := field.Tag.Get("timeFormat"); format {
+	case "":
+		return t, true
+	case timeFormatUnix:
+		return t.Unix(), true
+	case timeFormatUnixMilli:
+		return t.UnixMilli(), true
+	default:
+		return t.Format(format), true
+	}
+}
+
+// validateTimeFormatFields checks timeFormat tags are on time fields and name a usable layout.
+func validateTimeFormatFields(t reflect.Type) []string {
+	var errors []string
+	reference := time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+
+	var check func(reflect.Type)
+	check = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					check(embeddedType)
+					continue
+				}
+			}
+			format, ok := field.Tag.Lookup("timeFormat")
+			if !ok {
+				continue
+			}
+
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			switch {
+			case fieldType != timeType:
+				errors = append(errors, fmt.Sprintf("field %s: timeFormat requires a time.Time or *time.Time field, got %v", field.Name, field.Type))
+			case format == "":
+				errors = append(errors, fmt.Sprintf("field %s: timeFormat must not be empty", field.Name))
+			case format != timeFormatUnix && format != timeFormatUnixMilli && reference.Format(format) == format:
+				errors = append(errors, fmt.Sprintf("field %s: timeFormat %q is neither %q, %q nor a time layout", field.Name, format, timeFormatUnix, timeFormatUnixMilli))
+			}
+		}
+	}
+
+	check(t)
+	return errors
+}
@@ -0,0 +1,272 @@
+package typedb
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TimeTestEvent is a test model for time handling tests
+type TimeTestEvent struct {
+	Model
+	StartsAt  time.Time      `db:"starts_at"`
+	EndsAt    *time.Time     `db:"ends_at"`
+	CreatedAt time.Time      `db:"created_at" timeFormat:"unix"`
+	UpdatedAt *time.Time     `db:"updated_at" timeFormat:"unixmilli"`
+	Day       time.Time      `db:"day" timeFormat:"2006-01-02"`
+	Name      string         `db:"name"`
+	Length    time.Duration  `db:"length"`
+	Grace     *time.Duration `db:"grace"`
+	ID        int64          `db:"id" load:"primary"`
+}
+
+func (e *TimeTestEvent) TableName() string {
+	return "events"
+}
+
+func (e *TimeTestEvent) QueryByID() string {
+	return "SELECT id, name, starts_at, ends_at, created_at, updated_at, day, length, grace FROM events WHERE id = ?"
+}
+
+// TimeTestBadFormat has invalid timeFormat tags
+type TimeTestBadFormat struct {
+	Model
+	At    time.Time `db:"at" timeFormat:"yesterday"`
+	Count int       `db:"count" timeFormat:"unix"`
+	ID    int64     `db:"id"`
+}
+
+func TestDecodeTime_Location(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	row := map[string]any{"starts_at": "2024-01-02 03:04:05", "ends_at": []byte("2024-01-02T03:04:05Z")}
+
+	event, err := deserializeForType[*TimeTestEvent](row)
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC); !event.StartsAt.Equal(want) {
+		t.Errorf("Expected zone-less timestamp in UTC by default, got %v", event.StartsAt)
+	}
+
+	opts := deserializeOptions{times: timeSettings{location: newYork}}
+	event, err = deserializeForTypeWithOptions[*TimeTestEvent](row, opts)
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if want := time.Date(2024, 1, 2, 3, 4, 5, 0, newYork); !event.StartsAt.Equal(want) {
+		t.Errorf("Expected zone-less timestamp in New York, got %v", event.StartsAt)
+	}
+	if event.EndsAt == nil || event.EndsAt.Location() != time.UTC {
+		t.Errorf("Expected explicit zone to be kept without normalization, got %v", event.EndsAt)
+	}
+
+	opts.times.normalize = true
+	event, err = deserializeForTypeWithOptions[*TimeTestEvent](row, opts)
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if event.EndsAt.Location() != newYork || event.EndsAt.Hour() != 22 {
+		t.Errorf("Expected normalized New York time, got %v", event.EndsAt)
+	}
+}
+
+func TestDecodeTime_Formats(t *testing.T) {
+	event, err := deserializeForType[*TimeTestEvent](map[string]any{
+		"created_at": int64(1700000000),
+		"updated_at": "1700000000123",
+		"day":        "2024-03-04",
+	})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if !event.CreatedAt.Equal(time.Unix(1700000000, 0)) || event.CreatedAt.Location() != time.UTC {
+		t.Errorf("Unexpected CreatedAt %v", event.CreatedAt)
+	}
+	if event.UpdatedAt == nil || !event.UpdatedAt.Equal(time.UnixMilli(1700000000123)) {
+		t.Errorf("Unexpected UpdatedAt %v", event.UpdatedAt)
+	}
+	if want := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC); !event.Day.Equal(want) {
+		t.Errorf("Unexpected Day %v", event.Day)
+	}
+
+	if _, err := deserializeForType[*TimeTestEvent](map[string]any{"day": "04/03/2024"}); err == nil || !strings.Contains(err.Error(), "field day") {
+		t.Errorf("Expected layout error naming the field, got %v", err)
+	}
+	if _, err := deserializeForType[*TimeTestEvent](map[string]any{"created_at": "soon"}); err == nil {
+		t.Error("Expected epoch parse error")
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{input: "00:00:00", want: 0},
+		{input: "01:02:03", want: time.Hour + 2*time.Minute + 3*time.Second},
+		{input: "00:00:00.000123", want: 123 * time.Microsecond},
+		{input: "-00:00:01.5", want: -1500 * time.Millisecond},
+		{input: "3 days", want: 72 * time.Hour},
+		{input: "1 day 02:00:00", want: 26 * time.Hour},
+		{input: "-1 days +02:03:04", want: -24*time.Hour + 2*time.Hour + 3*time.Minute + 4*time.Second},
+		{input: "@ 1 day 2 hours 3 mins 4.5 secs", want: 26*time.Hour + 3*time.Minute + 4500*time.Millisecond},
+		{input: "@ 5 mins ago", want: -5 * time.Minute},
+		{input: "2 weeks", want: 14 * 24 * time.Hour},
+		{input: "P1DT2H3M4.5S", want: 26*time.Hour + 3*time.Minute + 4500*time.Millisecond},
+		{input: "PT0.000001S", want: time.Microsecond},
+		{input: "-PT5M", want: -5 * time.Minute},
+		{input: "1h30m", want: 90 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		got, err := parseInterval(tt.input)
+		if err != nil {
+			t.Errorf("parseInterval(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseInterval(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "1 mon", "2 years 3 days", "P1Y", "P1M", "5 fortnights", "1:2:3:4", "3"} {
+		if _, err := parseInterval(input); err == nil {
+			t.Errorf("parseInterval(%q) expected error", input)
+		}
+	}
+}
+
+func TestDecodeDuration_Fields(t *testing.T) {
+	event, err := deserializeForType[*TimeTestEvent](map[string]any{
+		"length": "01:30:00",
+		"grace":  []byte("5 mins"),
+	})
+	if err != nil {
+		t.Fatalf("deserialize failed: %v", err)
+	}
+	if event.Length != 90*time.Minute || event.Grace == nil || *event.Grace != 5*time.Minute {
+		t.Errorf("Unexpected durations %v %v", event.Length, event.Grace)
+	}
+
+	// Integer nanoseconds still convert directly
+	event, err = deserializeForType[*TimeTestEvent](map[string]any{"length": int64(time.Second)})
+	if err != nil || event.Length != time.Second {
+		t.Errorf("Expected 1s from nanoseconds, got %v, %v", event.Length, err)
+	}
+
+	if _, err := deserializeForType[*TimeTestEvent](map[string]any{"length": "1 mon"}); err == nil || !strings.Contains(err.Error(), "no fixed duration") {
+		t.Errorf("Expected month interval error, got %v", err)
+	}
+}
+
+func TestEncodeTime_SettingsAndFormats(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 123456789, newYork)
+	event := &TimeTestEvent{StartsAt: at, CreatedAt: at, UpdatedAt: &at, Day: at}
+
+	opts := serializeOptions{times: timeSettings{precision: time.Microsecond, normalize: true}}
+	columns, values, _, err := serializeModelFieldsWithOptions(event, "ID", opts)
+	if err != nil {
+		t.Fatalf("serialize failed: %v", err)
+	}
+	got := make(map[string]any)
+	for i, column := range columns {
+		got[column] = values[i]
+	}
+
+	startsAt, ok := got["starts_at"].(time.Time)
+	if !ok || startsAt.Location() != time.UTC || startsAt.Nanosecond() != 123456000 || !startsAt.Equal(at.Truncate(time.Microsecond)) {
+		t.Errorf("Expected truncated UTC time, got %v", got["starts_at"])
+	}
+	if got["created_at"] != at.Unix() {
+		t.Errorf("Expected unix seconds, got %v", got["created_at"])
+	}
+	if got["updated_at"] != at.UnixMilli() {
+		t.Errorf("Expected unix milliseconds, got %v", got["updated_at"])
+	}
+	if got["day"] != "2024-01-02" {
+		t.Errorf("Expected formatted day in UTC, got %v", got["day"])
+	}
+
+	// Without settings, times are passed through unchanged
+	columns, values, _, err = serializeModelFields(&TimeTestEvent{StartsAt: at}, "ID")
+	if err != nil || columns[0] != "starts_at" || values[0] != at {
+		t.Errorf("Expected unchanged time, got %v %v %v", columns, values, err)
+	}
+}
+
+func TestTimeSettings_SQLiteRoundTrip(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1),
+		WithTimeNormalization(true), WithTimePrecision(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE events (id INTEGER PRIMARY KEY, name TEXT, starts_at TEXT, ends_at TEXT, created_at INTEGER, updated_at INTEGER, day TEXT, length TEXT, grace TEXT)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	if _, err := db.Exec(ctx, "INSERT INTO events (id, name, starts_at, length) VALUES (1, 'raw', '2024-01-02 03:04:05', '1 day 00:00:01')"); err != nil {
+		t.Fatalf("Failed to insert row: %v", err)
+	}
+
+	raw := &TimeTestEvent{ID: 1}
+	if err := Load(ctx, db, raw); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC); !raw.StartsAt.Equal(want) || raw.StartsAt.Location() != time.UTC {
+		t.Errorf("Unexpected StartsAt %v", raw.StartsAt)
+	}
+	if raw.Length != 24*time.Hour+time.Second {
+		t.Errorf("Unexpected Length %v", raw.Length)
+	}
+
+	at := time.Date(2024, 6, 7, 8, 9, 10, 987654321, time.FixedZone("UTC+2", 2*60*60))
+	event := &TimeTestEvent{Name: "typed", StartsAt: at, CreatedAt: at, UpdatedAt: &at, Day: at}
+	if err := Insert(ctx, db, event); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	var createdAt int64
+	if err := db.GetInto(ctx, "SELECT created_at FROM events WHERE id = ?", []any{event.ID}, &createdAt); err != nil {
+		t.Fatalf("GetInto failed: %v", err)
+	}
+	if createdAt != at.Unix() {
+		t.Errorf("Expected stored epoch %d, got %d", at.Unix(), createdAt)
+	}
+
+	loaded := &TimeTestEvent{ID: event.ID}
+	if err := Load(ctx, db, loaded); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !loaded.StartsAt.Equal(at.Truncate(time.Millisecond)) || loaded.StartsAt.Location() != time.UTC {
+		t.Errorf("Expected millisecond-precision UTC StartsAt, got %v", loaded.StartsAt)
+	}
+	if !loaded.CreatedAt.Equal(at.Truncate(time.Second)) || loaded.UpdatedAt == nil || !loaded.UpdatedAt.Equal(at.Truncate(time.Millisecond)) {
+		t.Errorf("Unexpected epoch times %v %v", loaded.CreatedAt, loaded.UpdatedAt)
+	}
+	if want := time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC); !loaded.Day.Equal(want) {
+		t.Errorf("Unexpected Day %v", loaded.Day)
+	}
+}
+
+func TestValidateModel_TimeFormat(t *testing.T) {
+	if err := ValidateModel(&TimeTestEvent{}); err != nil {
+		t.Errorf("Expected valid model, got %v", err)
+	}
+
+	err := ValidateModel(&TimeTestBadFormat{})
+	if err == nil || !strings.Contains(err.Error(), "field At") || !strings.Contains(err.Error(), "field Count") {
+		t.Errorf("Expected timeFormat errors for At and Count, got %v", err)
+	}
+}
@@ -0,0 +1,251 @@
+package typedb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Tuple2 holds the two models deserialized from one row by QueryAll2.
+type Tuple2[A, B ModelInterface] struct {
+	First  A
+	Second B
+}
+
+// Tuple3 holds the three models deserialized from one row by QueryAll3.
+type Tuple3[A, B, C ModelInterface] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+type columnRangesKey struct{}
+
+// WithColumnRanges splits the result columns of QueryAll2 and QueryAll3 by position instead of by
+// table prefix. Each boundary is the index of the first column of the next model: for QueryAll2,
+// WithColumnRanges(ctx, 3) assigns columns 0-2 to the first model and the rest to the second.
+// Column names inside a range need no prefix, so the same name may appear in several ranges.
+//
+// Example:
+//
+//	ctx = typedb.WithColumnRanges(ctx, 2)
+//	pairs, err := typedb.QueryAll2[*User, *User](ctx, db,
+//	    "SELECT u.id, u.name, m.id, m.name FROM users u JOIN users m ON m.id = u.manager_id")
+func WithColumnRanges(ctx context.Context, boundaries ...int) context.Context {
+	return context.WithValue(ctx, columnRangesKey{}, boundaries)
+}
+
+// getColumnRanges extracts the column range boundaries from context.
+func getColumnRanges(ctx context.Context) []int {
+	boundaries, _ := ctx.Value(columnRangesKey{}).([]int)
+	return boundaries
+}
+
+// QueryAll2 executes a join query and deserializes each row into two models.
+// Returns an empty slice if no rows are found.
+//
+// Columns are distributed by table-qualified prefix: a column named "users.name" (typically
+// written as u.name AS "users.name") goes to the model whose TableName() is "users", as "name".
+// Models without TableName() use their snake_case type name. Columns without a known prefix go
+// to every model. Use WithColumnRanges to distribute columns by position instead.
+//
+// Each model is deserialized as if it were loaded on its own: its column policy applies to its
+// columns only, and its original copy is saved for partial updates.
+// A and B must be pointer types (e.g., *User).
+//
+// Example:
+//
+//	rows, err := typedb.QueryAll2[*User, *Post](ctx, db,
+//	    `SELECT u.id AS "users.id", u.name AS "users.name", p.id AS "posts.id", p.title AS "posts.title"
+//	     FROM users u JOIN posts p ON p.user_id = u.id`)
+//	for _, row := range rows {
+//	    fmt.Println(row.First.Name, row.Second.Title)
+//	}
+func QueryAll2[A, B ModelInterface](ctx context.Context, exec Executor, query string, args ...any) ([]Tuple2[A, B], error) {
+	rows, err := queryTuples(ctx, exec, []reflect.Type{modelTypeOf[A](), modelTypeOf[B]()}, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Tuple2[A, B], 0, len(rows))
+	for _, models := range rows {
+		result = append(result, Tuple2[A, B]{First: models[0].(A), Second: models[1].(B)})
+	}
+	return result, nil
+}
+
+// QueryAll3 executes a join query and deserializes each row into three models.
+// Columns are distributed as described for QueryAll2.
+// A, B and C must be pointer types (e.g., *User).
+func QueryAll3[A, B, C ModelInterface](ctx context.Context, exec Executor, query string, args ...any) ([]Tuple3[A, B, C], error) {
+	rows, err := queryTuples(ctx, exec, []reflect.Type{modelTypeOf[A](), modelTypeOf[B](), modelTypeOf[C]()}, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Tuple3[A, B, C], 0, len(rows))
+	for _, models := range rows {
+		result = append(result, Tuple3[A, B, C]{First: models[0].(A), Second: models[1].(B), Third: models[2].(C)})
+	}
+	return result, nil
+}
+
+// modelTypeOf returns the reflect.Type of the type parameter T.
+func modelTypeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// tupleColumn maps a result column to the models receiving it.
+type tupleColumn struct {
+	models []int // Indexes of the models receiving the column
+	name   string
+}
+
+// queryTuples executes query and deserializes each row into one new model per type.
+func queryTuples(ctx context.Context, exec Executor, types []reflect.Type, query string, args []any) ([][]ModelInterface, error) {
+	for _, t := range types {
+		if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+			return nil, fmt.Errorf("typedb: QueryAll%d requires pointer to struct types (e.g., *User), got %s", len(types), t)
+		}
+	}
+
+	opts := newDeserializeOptions(ctx, exec)
+	boundaries := getColumnRanges(ctx)
+	result := make([][]ModelInterface, 0)
+
+	var keys [][]string // Per model: row map key for each result column, "" if not received
+	var binary []bool
+	err := exec.QueryDo(ctx, query, args, func(rows *sql.Rows) error {
+		if keys == nil {
+			cols, err := rows.Columns()
+			if err != nil {
+				return err
+			}
+			columns, err := splitTupleColumns(cols, types, boundaries)
+			if err != nil {
+				return err
+			}
+			keys = tupleColumnKeys(columns, len(types), opts.preserveColumnCase)
+			binary = binaryColumns(rows)
+		}
+
+		values, err := scanRowValues(rows, len(keys[0]), binary)
+		if err != nil {
+			return err
+		}
+
+		models := make([]ModelInterface, len(types))
+		for i, t := range types {
+			row := make(map[string]any)
+			for col, key := range keys[i] {
+				if key != "" {
+					row[key] = values[col]
+				}
+			}
+			model, ok := reflect.New(t.Elem()).Interface().(ModelInterface)
+			if !ok {
+				return fmt.Errorf("typedb: type %s does not implement ModelInterface", t)
+			}
+			if err := deserializeWithOptions(row, model, opts); err != nil {
+				return err
+			}
+			models[i] = model
+		}
+		result = append(result, models)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// splitTupleColumns assigns each result column to one or more models, by position when
+// boundaries are set and by table prefix otherwise.
+func splitTupleColumns(cols []string, types []reflect.Type, boundaries []int) ([]tupleColumn, error) {
+	columns := make([]tupleColumn, len(cols))
+
+	if boundaries != nil {
+		if len(boundaries) != len(types)-1 {
+			return nil, fmt.Errorf("typedb: WithColumnRanges needs %d boundaries for %d models, got %d", len(types)-1, len(types), len(boundaries))
+		}
+		model, previous := 0, 0
+		for _, boundary := range boundaries {
+			if boundary <= previous || boundary >= len(cols) {
+				return nil, fmt.Errorf("typedb: invalid column range boundaries %v for %d columns", boundaries, len(cols))
+			}
+			previous = boundary
+		}
+		for i, col := range cols {
+			for model < len(boundaries) && i >= boundaries[model] {
+				model++
+			}
+			columns[i] = tupleColumn{name: col, models: []int{model}}
+		}
+		return columns, nil
+	}
+
+	prefixes := make([]string, len(types))
+	for i, t := range types {
+		prefix := toSnakeCase(t.Elem().Name())
+		if model, ok := reflect.New(t.Elem()).Interface().(ModelInterface); ok {
+			if tableName, err := getTableName(model); err == nil {
+				prefix = strings.ToLower(tableName)
+			}
+		}
+		for j := 0; j < i; j++ {
+			if prefixes[j] == prefix {
+				return nil, fmt.Errorf("typedb: %s and %s share the column prefix %q; use WithColumnRanges", types[j].Elem().Name(), t.Elem().Name(), prefix)
+			}
+		}
+		prefixes[i] = prefix
+	}
+
+	all := make([]int, len(types))
+	for i := range all {
+		all[i] = i
+	}
+	prefixed := false
+	for i, col := range cols {
+		columns[i] = tupleColumn{name: col, models: all}
+		lower := strings.ToLower(col)
+		for model, prefix := range prefixes {
+			if strings.HasPrefix(lower, prefix+".") {
+				columns[i] = tupleColumn{name: col[len(prefix)+1:], models: []int{model}}
+				prefixed = true
+				break
+			}
+		}
+	}
+	if !prefixed {
+		return nil, fmt.Errorf("typedb: no result column has a table prefix among %v (e.g., \"%s.id\"); alias the columns or use WithColumnRanges", prefixes, prefixes[0])
+	}
+
+	return columns, nil
+}
+
+// tupleColumnKeys returns, for each model, the row map key of each result column it receives,
+// or "" for columns it does not receive. Keys are assigned by columnKeys among the model's columns.
+func tupleColumnKeys(columns []tupleColumn, models int, preserveCase bool) [][]string {
+	keys := make([][]string, models)
+	for model := range keys {
+		var names []string
+		var indexes []int
+		for i, col := range columns {
+			for _, m := range col.models {
+				if m == model {
+					names = append(names, col.name)
+					indexes = append(indexes, i)
+				}
+			}
+		}
+		keys[model] = make([]string, len(columns))
+		for i, key := range columnKeys(names, preserveCase, true) {
+			keys[model][indexes[i]] = key
+		}
+	}
+	return keys
+}
@@ -0,0 +1,163 @@
+package typedb
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TupleTestUser is a test model for tuple queries
+type TupleTestUser struct {
+	Model
+	Name string `db:"name"`
+	ID   int64  `db:"id" load:"primary"`
+}
+
+func (u *TupleTestUser) TableName() string {
+	return "users"
+}
+
+func (u *TupleTestUser) QueryByID() string {
+	return "SELECT id, name FROM users WHERE id = ?"
+}
+
+// TupleTestPost is a test model for tuple queries
+type TupleTestPost struct {
+	Model
+	Title  string `db:"title"`
+	ID     int64  `db:"id" load:"primary"`
+	UserID int64  `db:"user_id"`
+}
+
+func (p *TupleTestPost) TableName() string {
+	return "posts"
+}
+
+func (p *TupleTestPost) QueryByID() string {
+	return "SELECT id, user_id, title FROM posts WHERE id = ?"
+}
+
+// TupleTestTag is a test model without TableName()
+type TupleTestTag struct {
+	Model
+	Label string `db:"label"`
+}
+
+func openTupleTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1), WithColumnPolicy(ColumnPolicyErrorOnUnknown))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, manager_id INTEGER)",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER, title TEXT)",
+		"INSERT INTO users (id, name, manager_id) VALUES (1, 'Alice', NULL), (2, 'Bob', 1)",
+		"INSERT INTO posts (id, user_id, title) VALUES (10, 1, 'First'), (11, 2, 'Second')",
+	} {
+		if _, err := db.Exec(context.Background(), stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+	return db
+}
+
+func TestQueryAll2_TablePrefixes(t *testing.T) {
+	withModelOptions(t, reflect.TypeOf(TupleTestUser{}), ModelOptions{PartialUpdate: true})
+	withModelOptions(t, reflect.TypeOf(TupleTestPost{}), ModelOptions{PartialUpdate: true})
+
+	db := openTupleTestDB(t)
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	rows, err := QueryAll2[*TupleTestUser, *TupleTestPost](ctx, db, `
+		SELECT u.id AS "users.id", u.name AS "Users.Name", p.id AS "posts.id", p.title AS "posts.title", p.user_id AS "posts.user_id"
+		FROM users u JOIN posts p ON p.user_id = u.id
+		ORDER BY p.id`)
+	if err != nil {
+		t.Fatalf("QueryAll2 failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if rows[0].First.ID != 1 || rows[0].First.Name != "Alice" || rows[0].Second.ID != 10 || rows[0].Second.Title != "First" {
+		t.Errorf("Unexpected first row %+v %+v", rows[0].First, rows[0].Second)
+	}
+	if rows[1].First.Name != "Bob" || rows[1].Second.UserID != 2 {
+		t.Errorf("Unexpected second row %+v %+v", rows[1].First, rows[1].Second)
+	}
+
+	user, post := rows[0].First, rows[0].Second
+	user.Name = "Alicia"
+	if changed, err := getChangedFields(user, "ID"); err != nil || !reflect.DeepEqual(changed, map[string]bool{"name": true}) {
+		t.Errorf("Expected only name changed on the first model, got %v, %v", changed, err)
+	}
+	if changed, err := getChangedFields(post, "ID"); err != nil || len(changed) != 0 {
+		t.Errorf("Expected no changes on the second model, got %v, %v", changed, err)
+	}
+}
+
+func TestQueryAll3_SharedColumnsAndTypeNamePrefix(t *testing.T) {
+	db := openTupleTestDB(t)
+	defer closeDB(t, db)
+
+	ctx := WithColumnPolicyOverride(context.Background(), ColumnPolicyIgnore)
+	rows, err := QueryAll3[*TupleTestUser, *TupleTestPost, *TupleTestTag](ctx, db, `
+		SELECT u.id AS "users.id", p.id AS "posts.id", 'draft' AS "tuple_test_tag.label", u.name
+		FROM users u JOIN posts p ON p.user_id = u.id
+		WHERE u.id = 2`)
+	if err != nil {
+		t.Fatalf("QueryAll3 failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(rows))
+	}
+	if rows[0].First.ID != 2 || rows[0].First.Name != "Bob" || rows[0].Second.ID != 11 || rows[0].Third.Label != "draft" {
+		t.Errorf("Unexpected row %+v %+v %+v", rows[0].First, rows[0].Second, rows[0].Third)
+	}
+}
+
+func TestQueryAll2_ColumnRanges(t *testing.T) {
+	db := openTupleTestDB(t)
+	defer closeDB(t, db)
+
+	ctx := WithColumnRanges(context.Background(), 2)
+	rows, err := QueryAll2[*TupleTestUser, *TupleTestUser](ctx, db, `
+		SELECT u.id, u.name, m.id, m.name
+		FROM users u JOIN users m ON m.id = u.manager_id`)
+	if err != nil {
+		t.Fatalf("QueryAll2 failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0].First.Name != "Bob" || rows[0].Second.Name != "Alice" || rows[0].Second.ID != 1 {
+		t.Fatalf("Unexpected rows %+v", rows)
+	}
+}
+
+func TestQueryAll2_Errors(t *testing.T) {
+	db := openTupleTestDB(t)
+	defer closeDB(t, db)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "no prefixes", ctx: context.Background(), want: "no result column has a table prefix"},
+		{name: "boundary count", ctx: WithColumnRanges(context.Background(), 1, 2), want: "needs 1 boundaries"},
+		{name: "boundary out of range", ctx: WithColumnRanges(context.Background(), 2), want: "invalid column range boundaries"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := QueryAll2[*TupleTestUser, *TupleTestPost](tt.ctx, db, "SELECT id, name FROM users")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	_, err := QueryAll2[*TupleTestUser, *TupleTestUser](context.Background(), db, "SELECT id FROM users")
+	if err == nil || !strings.Contains(err.Error(), "share the column prefix") {
+		t.Errorf("Expected shared prefix error, got %v", err)
+	}
+}
@@ -28,36 +28,51 @@ type Executor interface {
 // DB wraps *sql.DB and provides query execution with timeout handling.
 // DB implements the Executor interface.
 type DB struct {
-	logger     Logger
-	db         *sql.DB
-	driverName string
-	timeout    time.Duration
-	logQueries bool
-	logArgs    bool
+	logger             Logger
+	db                 *sql.DB
+	codecs             *codecRegistry
+	times              timeSettings
+	driverName         string
+	timeout            time.Duration
+	logQueries         bool
+	logArgs            bool
+	preserveColumnCase bool
+	columnPolicy       ColumnPolicy
 }
 
 // Tx wraps *sql.Tx and provides transaction-scoped query execution.
 // Tx implements the Executor interface.
 type Tx struct {
-	logger     Logger
-	tx         *sql.Tx
-	driverName string
-	timeout    time.Duration
-	logQueries bool
-	logArgs    bool
+	logger             Logger
+	tx                 *sql.Tx
+	codecs             *codecRegistry
+	times              timeSettings
+	driverName         string
+	timeout            time.Duration
+	logQueries         bool
+	logArgs            bool
+	preserveColumnCase bool
+	columnPolicy       ColumnPolicy
 }
 
 // Config holds database connection and pool configuration.
 type Config struct {
-	Logger          Logger
-	DSN             string
-	ConnMaxLifetime time.Duration
-	ConnMaxIdleTime time.Duration
-	OpTimeout       time.Duration
-	MaxOpenConns    int
-	MaxIdleConns    int
-	LogQueries      bool
-	LogArgs         bool
+	Logger             Logger
+	codecs             *codecRegistry
+	TimeLocation       *time.Location
+	DSN                string
+	ConnMaxLifetime    time.Duration
+	ConnMaxIdleTime    time.Duration
+	OpTimeout          time.Duration
+	TimePrecision      time.Duration
+	MaxOpenConns       int
+	MaxIdleConns       int
+	LogQueries         bool
+	LogArgs            bool
+	ValidateQueries    bool
+	NormalizeTimes     bool
+	PreserveColumnCase bool
+	ColumnPolicy       ColumnPolicy
 }
 
 // ModelInterface defines the contract for model types that can be deserialized.
@@ -27,6 +27,13 @@ import (
 // This requires keeping a copy of the deserialized object, which uses additional memory.
 // The original copy is automatically saved after deserialization and refreshed after successful updates.
 //
+// Models with a dbDelete field are only updated if the row is not soft-deleted: the WHERE clause
+// excludes soft-deleted rows and Update returns ErrNotFound if no row matched.
+// Use WithUpdateSoftDeleted to allow updating soft-deleted rows. When the update reports no affected
+// rows, a SELECT checks whether the row exists, since MySQL counts only rows whose values changed.
+//
+// Models implementing BeforeUpdateHook or AfterUpdateHook have them called before and after the update.
+//
 // Example (standard update):
 //
 //	type User struct {
@@ -137,19 +144,27 @@ func Update[T ModelInterface](ctx context.Context, exec Executor, model T) error
 		return fmt.Errorf("typedb: Update requires primary key field %s to be set (non-zero value)", primaryField.Name)
 	}
 
+	if err := runBeforeUpdate(ctx, exec, model); err != nil {
+		return err
+	}
+
+	if err := validateModelFields(model, primaryField.Name, true, getExecutorCodecs(exec)); err != nil {
+		return err
+	}
+
 	driverName := getDriverName(exec)
 	structType := reflect.TypeOf(model).Elem()
 	opts := GetModelOptions(structType)
 	var changedFields map[string]bool
 	if opts.PartialUpdate {
-		changedFields, err = getChangedFields(model, primaryField.Name)
+		changedFields, err = getChangedFieldsWithCodecs(model, primaryField.Name, getExecutorCodecs(exec))
 		if err != nil {
 			return fmt.Errorf("typedb: Update failed to get changed fields: %w", err)
 		}
 	}
 
 	// Serialize fields
-	columns, values, autoUpdateColumns, maskIndices, err := serializeModelFieldsForUpdate(model, primaryField.Name, driverName, changedFields)
+	columns, values, autoUpdateColumns, maskIndices, err := serializeModelFieldsForUpdateWithOptions(model, primaryField.Name, changedFields, newSerializeOptions(exec))
 	if err != nil {
 		return fmt.Errorf("typedb: Update failed to serialize model: %w", err)
 	}
@@ -158,19 +173,53 @@ func Update[T ModelInterface](ctx context.Context, exec Executor, model T) error
 		return fmt.Errorf("typedb: Update requires at least one non-nil field to update")
 	}
 
+	queryCtx := ctx
 	if len(maskIndices) > 0 {
 		ctx = WithMaskIndices(ctx, maskIndices)
 	}
 
 	// Build query
 	query, allValues := buildUpdateQuery(driverName, tableName, primaryKeyColumn, columns, values, autoUpdateColumns)
-	allValues[len(allValues)-1] = primaryKeyValue.Interface()
+	allValues[len(allValues)-1], err = serializeFieldValue(primaryKeyValue, newSerializeOptions(exec))
+	if err != nil {
+		return fmt.Errorf("typedb: Update failed to serialize primary key value: %w", err)
+	}
+
+	// Leave soft-deleted rows untouched unless explicitly allowed
+	softDelete, guarded := findSoftDeleteColumn(model)
+	guarded = guarded && !isUpdateSoftDeleted(ctx)
+	if guarded {
+		query += " AND " + softDelete.notDeletedCondition(driverName)
+	}
 
 	// Execute
-	_, err = exec.Exec(ctx, query, allValues...)
+	result, err := exec.Exec(ctx, query, allValues...)
 	if err != nil {
 		return fmt.Errorf("typedb: Update failed: %w", err)
 	}
+	if guarded {
+		if result == nil {
+			return fmt.Errorf("typedb: Update returned nil result")
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("typedb: Update failed to get rows affected: %w", err)
+		}
+		if affected == 0 {
+			// MySQL reports changed rows, so an update writing the current values also affects none
+			condition := fmt.Sprintf("%s = %s AND %s",
+				quoteIdentifier(driverName, primaryKeyColumn),
+				generatePlaceholder(driverName, 1),
+				softDelete.notDeletedCondition(driverName))
+			exists, err := rowExists(queryCtx, exec, driverName, tableName, condition, allValues[len(allValues)-1])
+			if err != nil {
+				return fmt.Errorf("typedb: Update failed to check for the row: %w", err)
+			}
+			if !exists {
+				return fmt.Errorf("typedb: Update matched no row that is not soft-deleted: %w", ErrNotFound)
+			}
+		}
+	}
 
 	// If partial update is enabled, refresh the original copy after successful update
 	if opts.PartialUpdate {
@@ -184,7 +233,17 @@ func Update[T ModelInterface](ctx context.Context, exec Executor, model T) error
 		}
 	}
 
-	return nil
+	return runAfterUpdate(ctx, exec, model)
+}
+
+// rowExists reports whether tableName has a row matching condition.
+func rowExists(ctx context.Context, exec Executor, driverName, tableName, condition string, args ...any) (bool, error) {
+	query := fmt.Sprintf("SELECT 1 AS found FROM %s WHERE %s", quoteIdentifier(driverName, tableName), condition)
+	rows, err := exec.QueryAll(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	return len(rows) > 0, nil
 }
 
 // getTimestampFunction returns the database-specific function for getting the current timestamp.
@@ -207,7 +266,11 @@ func getTimestampFunction(driverName string) string {
 
 // serializeModelFieldsForUpdate collects non-nil/non-zero fields from a model for UPDATE operations.
 func serializeModelFieldsForUpdate(model ModelInterface, primaryKeyFieldName, driverName string, changedFields map[string]bool) (columns []string, values []any, autoUpdateColumns []string, maskIndices []int, err error) {
-	_ = driverName // Reserved for future use (e.g., database-specific field handling)
+	return serializeModelFieldsForUpdateWithOptions(model, primaryKeyFieldName, changedFields, serializeOptions{driverName: driverName})
+}
+
+// serializeModelFieldsForUpdateWithOptions is serializeModelFieldsForUpdate with DB-level settings applied.
+func serializeModelFieldsForUpdateWithOptions(model ModelInterface, primaryKeyFieldName string, changedFields map[string]bool, opts serializeOptions) (columns []string, values []any, autoUpdateColumns []string, maskIndices []int, err error) {
 	modelValue := reflect.ValueOf(model)
 	if modelValue.Kind() != reflect.Ptr || modelValue.IsNil() {
 		return nil, nil, nil, nil, fmt.Errorf("typedb: model must be a non-nil pointer")
@@ -223,7 +286,7 @@ func serializeModelFieldsForUpdate(model ModelInterface, primaryKeyFieldName, dr
 	autoUpdateColumns = []string{}
 	maskIndices = []int{}
 
-	iterateStructFields(modelValue.Type(), modelValue, primaryKeyFieldName, func(field reflect.StructField, fieldValue reflect.Value, columnName string) bool {
+	iterateStructFields(modelValue.Type(), modelValue, primaryKeyFieldName, opts.codecs, func(field reflect.StructField, fieldValue reflect.Value, columnName string) bool {
 		// Check for dbUpdate tag
 		dbUpdateTag := field.Tag.Get("dbUpdate")
 
@@ -241,6 +304,11 @@ func serializeModelFieldsForUpdate(model ModelInterface, primaryKeyFieldName, dr
 			return true
 		}
 
+		// Unset Null[T] fields are never written
+		if _, _, unset, ok := asNullSource(fieldValue); ok && unset {
+			return true
+		}
+
 		// If partial update is enabled, only include changed fields
 		if changedFields != nil {
 			if !changedFields[columnName] {
@@ -257,9 +325,14 @@ func serializeModelFieldsForUpdate(model ModelInterface, primaryKeyFieldName, dr
 					// Pointer/slice/map changed to nil - write NULL
 					values = append(values, nil)
 				} else {
-					// Value type changed to zero (false, 0, "", 0.0) - write the actual value.
-					// Important: empty string "" stores as "" (not NULL); SQL treats "" and NULL as distinct.
-					values = append(values, fieldValue.Interface())
+					// Value type changed to zero (false, 0, "", 0.0) or an invalid Null* - write the
+					// serialized value. Important: empty string "" stores as "" (not NULL); SQL treats "" and NULL as distinct.
+					value, valueErr := serializeColumnValue(field, fieldValue, opts)
+					if valueErr != nil {
+						err = fmt.Errorf("typedb: field %s: %w", field.Name, valueErr)
+						return false
+					}
+					values = append(values, value)
 				}
 				return true
 			}
@@ -269,15 +342,24 @@ func serializeModelFieldsForUpdate(model ModelInterface, primaryKeyFieldName, dr
 			return true
 		}
 
+		value, valueErr := serializeColumnValue(field, fieldValue, opts)
+		if valueErr != nil {
+			err = fmt.Errorf("typedb: field %s: %w", field.Name, valueErr)
+			return false
+		}
+
 		shouldMask := field.Tag.Get("nolog") == "true"
 		if shouldMask {
 			maskIndices = append(maskIndices, len(values))
 		}
 
 		columns = append(columns, columnName)
-		values = append(values, fieldValue.Interface())
+		values = append(values, value)
 		return true
 	})
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
 
 	return columns, values, autoUpdateColumns, maskIndices, nil
 }
@@ -338,6 +420,11 @@ func compareFieldMaps(currentFields, originalFields map[string]reflect.Value) ma
 // a map of column names that have changed. Returns nil if partial update is not enabled
 // or if no original copy exists.
 func getChangedFields(model ModelInterface, primaryKeyFieldName string) (changedFields map[string]bool, err error) {
+	return getChangedFieldsWithCodecs(model, primaryKeyFieldName, nil)
+}
+
+// getChangedFieldsWithCodecs is getChangedFields for an executor with DB-level codecs.
+func getChangedFieldsWithCodecs(model ModelInterface, primaryKeyFieldName string, dbCodecs *codecRegistry) (changedFields map[string]bool, err error) {
 	modelValue := reflect.ValueOf(model)
 	if modelValue.Kind() != reflect.Ptr || modelValue.IsNil() {
 		return nil, fmt.Errorf("model must be a non-nil pointer")
@@ -368,8 +455,8 @@ func getChangedFields(model ModelInterface, primaryKeyFieldName string) (changed
 	}
 
 	// Build field maps for comparison
-	currentFields := buildFieldMapForComparison(structValue, primaryKeyFieldName)
-	originalFields := buildFieldMapForComparison(originalStructValue, primaryKeyFieldName)
+	currentFields := buildFieldMapForComparison(structValue, primaryKeyFieldName, dbCodecs)
+	originalFields := buildFieldMapForComparison(originalStructValue, primaryKeyFieldName, dbCodecs)
 
 	// Compare and return changed fields
 	return compareFieldMaps(currentFields, originalFields), nil
@@ -377,10 +464,27 @@ func getChangedFields(model ModelInterface, primaryKeyFieldName string) (changed
 
 // buildFieldMapForComparison builds a map of column names to field values for comparison.
 // Excludes primary key and fields with db:"-" tag.
-func buildFieldMapForComparison(structValue reflect.Value, primaryKeyFieldName string) map[string]reflect.Value {
+func buildFieldMapForComparison(structValue reflect.Value, primaryKeyFieldName string, dbCodecs *codecRegistry) map[string]reflect.Value {
 	fieldMap := make(map[string]reflect.Value)
 
-	iterateStructFields(structValue.Type(), structValue, primaryKeyFieldName, func(field reflect.StructField, fieldValue reflect.Value, columnName string) bool {
+	iterateStructFields(structValue.Type(), structValue, primaryKeyFieldName, dbCodecs, func(field reflect.StructField, fieldValue reflect.Value, columnName string) bool {
+		if isJSONField(field) {
+			// Compare JSON columns by their encoded form, as that is what is written
+			if encoded, err := encodeJSONColumn(field, fieldValue); err == nil {
+				fieldMap[columnName] = reflect.ValueOf(&encoded).Elem()
+				return true
+			}
+		}
+		// Compare Null[T] by validity and value, so unset and NULL are equivalent
+		if comparable, ok := comparableNull(fieldValue); ok {
+			fieldMap[columnName] = comparable
+			return true
+		}
+		// Compare decimals numerically, so 1.5 and 1.50 are unchanged
+		if comparable, ok := comparableDecimal(fieldValue); ok {
+			fieldMap[columnName] = comparable
+			return true
+		}
 		fieldMap[columnName] = fieldValue
 		return true
 	})
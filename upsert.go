@@ -0,0 +1,583 @@
+package typedb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type upsertDoNothingKey struct{}
+
+// WithUpsertDoNothing makes Upsert leave an existing row unchanged instead of updating it.
+// The primary key is only set on the model when a row was inserted (on MySQL and Oracle it is
+// set in both cases).
+//
+// Example:
+//
+//	err := typedb.Upsert(typedb.WithUpsertDoNothing(ctx), db, user, "Email")
+//	// Generates: INSERT INTO users (email, name) VALUES ($1, $2) ON CONFLICT (email) DO NOTHING RETURNING id
+func WithUpsertDoNothing(ctx context.Context) context.Context {
+	return context.WithValue(ctx, upsertDoNothingKey{}, true)
+}
+
+// isUpsertDoNothing reports whether WithUpsertDoNothing was set on the context.
+func isUpsertDoNothing(ctx context.Context) bool {
+	doNothing, _ := ctx.Value(upsertDoNothingKey{}).(bool)
+	return doNothing
+}
+
+type upsertSkipDeletedKey struct{}
+
+// WithUpsertSkipDeleted makes Upsert leave a conflicting soft-deleted row unchanged instead of
+// restoring it. Only models with a dbDelete field are affected. As with WithUpsertDoNothing, the
+// primary key is only set when a row was inserted (on MySQL and Oracle it is set in both cases).
+//
+// Example:
+//
+//	err := typedb.Upsert(typedb.WithUpsertSkipDeleted(ctx), db, user, "Email")
+//	// Generates: ... ON CONFLICT (email) DO UPDATE SET name = EXCLUDED.name WHERE users.deleted_at IS NULL RETURNING id
+func WithUpsertSkipDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, upsertSkipDeletedKey{}, true)
+}
+
+// isUpsertSkipDeleted reports whether WithUpsertSkipDeleted was set on the context.
+func isUpsertSkipDeleted(ctx context.Context) bool {
+	skip, _ := ctx.Value(upsertSkipDeletedKey{}).(bool)
+	return skip
+}
+
+// upsertStatement is the parts of an upsert shared by all dialects.
+type upsertStatement struct {
+	driverName       string
+	tableName        string
+	primaryKeyColumn string
+	columns          []string // Inserted columns
+	values           []any
+	conflictColumns  []string
+	updateColumns    []string          // Inserted columns written on conflict
+	autoUpdate       []string          // dbUpdate:"auto-timestamp" columns written on conflict
+	softDelete       *softDeleteColumn // The dbDelete column, if the model has one
+	skipDeleted      bool              // Leave soft-deleted rows unchanged instead of restoring them
+	doNothing        bool
+}
+
+// Upsert inserts a model, or updates the existing row if the insert conflicts with it.
+// The conflict target is the named fields (struct field names); by default it is the
+// load:"primary" field if set, otherwise the first load:"unique" field that is set.
+// Conflict fields must be set, and the database must have a unique constraint on their columns.
+//
+// The statement is built per driver:
+//   - PostgreSQL, SQLite: INSERT ... ON CONFLICT (...) DO UPDATE SET ... RETURNING pk
+//   - MySQL: INSERT ... ON DUPLICATE KEY UPDATE ... (MySQL matches any unique key, not only the target)
+//   - SQL Server: MERGE ... WITH (HOLDLOCK) ... OUTPUT INSERTED.pk
+//   - Oracle: MERGE ... followed by a SELECT of the primary key when the target is not the primary key
+//
+// Non-zero fields are inserted as by Insert, excluding dbInsert:"false" fields; the primary key is
+// inserted when set. On conflict, the inserted columns other than the conflict columns are updated,
+// excluding dbUpdate:"false" fields, and dbUpdate:"auto-timestamp" fields are set to the database
+// timestamp. Use WithUpsertDoNothing to leave existing rows unchanged.
+//
+// For models with a dbDelete field, a conflicting soft-deleted row is restored: its delete column is
+// cleared along with the update. Use WithUpsertSkipDeleted to leave soft-deleted rows unchanged.
+//
+// Since only the database knows whether the row was inserted or updated, BeforeInsert and BeforeUpdate
+// hooks both run before the model is serialized, and AfterInsert and AfterUpdate hooks both run after
+// the statement; the update hooks are skipped with WithUpsertDoNothing. Validate tags are checked as
+// for Insert. The primary key field is set on the model afterward.
+//
+// Example:
+//
+//	user := &User{Email: "john@example.com", Name: "John"}
+//	err := typedb.Upsert(ctx, db, user, "Email")
+//	// Generates: INSERT INTO users (email, name) VALUES ($1, $2)
+//	//   ON CONFLICT (email) DO UPDATE SET name = EXCLUDED.name RETURNING id
+func Upsert[T ModelInterface](ctx context.Context, exec Executor, model T, conflictFields ...string) error {
+	tableName, err := getTableName(model)
+	if err != nil {
+		return fmt.Errorf("typedb: Upsert validation failed: %w", err)
+	}
+
+	if hasDotNotation(model) {
+		return fmt.Errorf("typedb: Upsert cannot be used with joined models (detected dot notation in db tags)")
+	}
+
+	primaryField, found := findFieldByTag(model, "load", "primary")
+	if !found {
+		return fmt.Errorf("typedb: Upsert requires a field with load:\"primary\" tag")
+	}
+
+	primaryKeyColumn := primaryField.Tag.Get("db")
+	if primaryKeyColumn == "" || primaryKeyColumn == "-" {
+		return fmt.Errorf("typedb: primary key field %s must have a db tag", primaryField.Name)
+	}
+
+	stmt := upsertStatement{
+		driverName:       getDriverName(exec),
+		tableName:        tableName,
+		primaryKeyColumn: primaryKeyColumn,
+		doNothing:        isUpsertDoNothing(ctx),
+		skipDeleted:      isUpsertSkipDeleted(ctx),
+	}
+	if column, ok := findSoftDeleteColumn(model); ok {
+		stmt.softDelete = &column
+	}
+
+	// Hooks run before the conflict fields are read, so normalization applies to the conflict target
+	if err := runBeforeInsert(ctx, exec, model); err != nil {
+		return err
+	}
+	if !stmt.doNothing {
+		if err := runBeforeUpdate(ctx, exec, model); err != nil {
+			return err
+		}
+	}
+
+	if err := validateModelFields(model, primaryField.Name, false, getExecutorCodecs(exec)); err != nil {
+		return err
+	}
+
+	conflictColumns, err := upsertConflictColumns(model, primaryField, conflictFields)
+	if err != nil {
+		return err
+	}
+	stmt.conflictColumns = conflictColumns
+
+	maskIndices, err := stmt.collectColumns(exec, model, primaryField)
+	if err != nil {
+		return err
+	}
+
+	for _, column := range stmt.conflictColumns {
+		if !slices.Contains(stmt.columns, column) {
+			return fmt.Errorf("typedb: Upsert conflict column %s is not inserted (dbInsert:\"false\")", column)
+		}
+	}
+
+	hookCtx := ctx
+	if len(maskIndices) > 0 {
+		ctx = WithMaskIndices(ctx, maskIndices)
+	}
+
+	switch strings.ToLower(stmt.driverName) {
+	case "mysql":
+		err = upsertMySQL(ctx, exec, model, stmt, primaryField)
+	case "oracle":
+		err = upsertOracle(ctx, exec, model, stmt, primaryField)
+	default:
+		err = upsertWithReturning(ctx, exec, model, stmt, primaryField)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := runAfterInsert(hookCtx, exec, model); err != nil {
+		return err
+	}
+	if stmt.doNothing {
+		return nil
+	}
+	return runAfterUpdate(hookCtx, exec, model)
+}
+
+// upsertConflictColumns returns the columns of the named conflict fields, or of the default
+// conflict target: the primary key if set, otherwise the first unique field that is set.
+func upsertConflictColumns(model ModelInterface, primaryField *reflect.StructField, conflictFields []string) ([]string, error) {
+	modelType := getModelType(model)
+	if len(conflictFields) == 0 {
+		if value, err := getFieldValue(model, primaryField.Name); err == nil && !value.IsZero() {
+			return []string{primaryField.Tag.Get("db")}, nil
+		}
+		uniqueField := firstSetUniqueField(model, modelType)
+		if uniqueField == nil {
+			return nil, fmt.Errorf("typedb: Upsert requires conflict fields, a set load:\"primary\" field or a set load:\"unique\" field")
+		}
+		conflictFields = []string{uniqueField.Name}
+	}
+
+	columns := make([]string, len(conflictFields))
+	for i, fieldName := range conflictFields {
+		field, found := modelType.FieldByName(fieldName)
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, fieldName)
+		}
+		column := field.Tag.Get("db")
+		if column == "" || column == "-" {
+			return nil, fmt.Errorf("typedb: conflict field %s must have a db tag", fieldName)
+		}
+		value, err := getFieldValue(model, fieldName)
+		if err != nil {
+			return nil, fmt.Errorf("typedb: failed to get field %s value: %w", fieldName, err)
+		}
+		if value.IsZero() {
+			return nil, fmt.Errorf("typedb: Upsert conflict field %s is not set", fieldName)
+		}
+		columns[i] = column
+	}
+	return columns, nil
+}
+
+// firstSetUniqueField returns the first load:"unique" field of t, in declaration order, whose value is set.
+func firstSetUniqueField(model ModelInterface, t reflect.Type) *reflect.StructField {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				if found := firstSetUniqueField(model, embeddedType); found != nil {
+					return found
+				}
+				continue
+			}
+		}
+		if !containsTagValue(field.Tag.Get("load"), "unique") {
+			continue
+		}
+		if value, err := getFieldValue(model, field.Name); err == nil && !value.IsZero() {
+			return &field
+		}
+	}
+	return nil
+}
+
+// collectColumns serializes the inserted columns and determines the columns written on conflict.
+// Returns the indices of values to mask in logs.
+func (s *upsertStatement) collectColumns(exec Executor, model ModelInterface, primaryField *reflect.StructField) ([]int, error) {
+	columns, values, maskIndices, err := serializeModelFieldsWithOptions(model, primaryField.Name, newSerializeOptions(exec))
+	if err != nil {
+		return nil, fmt.Errorf("typedb: Upsert failed to serialize model: %w", err)
+	}
+
+	// The primary key is inserted when set, so it can be the conflict target
+	primaryKeyValue, err := getFieldValue(model, primaryField.Name)
+	if err != nil {
+		return nil, fmt.Errorf("typedb: Upsert failed to get primary key value: %w", err)
+	}
+	if !isZeroOrNil(primaryKeyValue) {
+		value, err := serializeFieldValue(primaryKeyValue, newSerializeOptions(exec))
+		if err != nil {
+			return nil, fmt.Errorf("typedb: Upsert failed to serialize primary key value: %w", err)
+		}
+		columns = append([]string{s.primaryKeyColumn}, columns...)
+		values = append([]any{value}, values...)
+		for i := range maskIndices {
+			maskIndices[i]++
+		}
+		if primaryField.Tag.Get("nolog") == "true" {
+			maskIndices = append([]int{0}, maskIndices...)
+		}
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("typedb: Upsert requires at least one non-nil field to insert")
+	}
+	s.columns = columns
+	s.values = values
+
+	noUpdate := make(map[string]bool)
+	for _, column := range s.conflictColumns {
+		noUpdate[column] = true
+	}
+	modelValue := reflect.ValueOf(model).Elem()
+	iterateStructFields(modelValue.Type(), modelValue, primaryField.Name, getExecutorCodecs(exec), func(field reflect.StructField, fieldValue reflect.Value, columnName string) bool {
+		switch field.Tag.Get("dbUpdate") {
+		case "false":
+			noUpdate[columnName] = true
+		case "auto-timestamp":
+			noUpdate[columnName] = true
+			s.autoUpdate = append(s.autoUpdate, columnName)
+		}
+		return true
+	})
+	// The primary key identifies the row and is never updated
+	noUpdate[s.primaryKeyColumn] = true
+	// The delete column is only written to restore the row
+	if s.softDelete != nil {
+		noUpdate[s.softDelete.name] = true
+	}
+
+	for _, column := range columns {
+		if !noUpdate[column] {
+			s.updateColumns = append(s.updateColumns, column)
+		}
+	}
+	return maskIndices, nil
+}
+
+// quotedColumns returns the inserted columns, quoted for the driver.
+func (s *upsertStatement) quotedColumns() []string {
+	quoted := make([]string, len(s.columns))
+	for i, column := range s.columns {
+		quoted[i] = quoteIdentifier(s.driverName, column)
+	}
+	return quoted
+}
+
+// setClauses returns the assignments made on conflict, reading the inserted value of a column with
+// insertedValue. A soft-deleted row is restored unless skipDeleted is set. When nothing would be
+// assigned, the first conflict column is assigned to itself so the statement still returns the
+// existing row.
+func (s *upsertStatement) setClauses(target string, insertedValue func(quotedColumn string) string) []string {
+	var clauses []string
+	for _, column := range s.updateColumns {
+		quoted := quoteIdentifier(s.driverName, column)
+		clauses = append(clauses, fmt.Sprintf("%s%s = %s", target, quoted, insertedValue(quoted)))
+	}
+	timestampFunc := getTimestampFunction(s.driverName)
+	for _, column := range s.autoUpdate {
+		clauses = append(clauses, fmt.Sprintf("%s%s = %s", target, quoteIdentifier(s.driverName, column), timestampFunc))
+	}
+	if s.restoresDeleted() {
+		clauses = append(clauses, fmt.Sprintf("%s%s = %s", target, quoteIdentifier(s.driverName, s.softDelete.name), s.softDelete.restoredValue(s.driverName)))
+	}
+	if len(clauses) == 0 {
+		quoted := quoteIdentifier(s.driverName, s.conflictColumns[0])
+		clauses = append(clauses, fmt.Sprintf("%s%s = %s", target, quoted, insertedValue(quoted)))
+	}
+	return clauses
+}
+
+// restoresDeleted reports whether a conflicting soft-deleted row is restored by the update.
+func (s *upsertStatement) restoresDeleted() bool {
+	return s.softDelete != nil && !s.skipDeleted
+}
+
+// skipsDeleted reports whether the update must leave soft-deleted rows unchanged.
+func (s *upsertStatement) skipsDeleted() bool {
+	return s.softDelete != nil && s.skipDeleted
+}
+
+// hasUpdates reports whether the update assigns any column besides the self-assignment fallback.
+func (s *upsertStatement) hasUpdates() bool {
+	return len(s.updateColumns) > 0 || len(s.autoUpdate) > 0 || s.restoresDeleted()
+}
+
+// upsertWithReturning upserts with INSERT ... ON CONFLICT ... RETURNING (PostgreSQL, SQLite)
+// or MERGE ... OUTPUT (SQL Server), setting the primary key from the returned row.
+func upsertWithReturning[T ModelInterface](ctx context.Context, exec Executor, model T, stmt upsertStatement, primaryField *reflect.StructField) error {
+	quotedTableName, quotedColumns, placeholders := buildInsertQueryParts(stmt.driverName, stmt.tableName, stmt.columns, stmt.values)
+	quotedPK := quoteIdentifier(stmt.driverName, stmt.primaryKeyColumn)
+	quotedConflict := make([]string, len(stmt.conflictColumns))
+	for i, column := range stmt.conflictColumns {
+		quotedConflict[i] = quoteIdentifier(stmt.driverName, column)
+	}
+
+	var query string
+	switch strings.ToLower(stmt.driverName) {
+	case "sqlserver", "mssql":
+		sourceColumns := make([]string, len(quotedColumns))
+		for i, column := range quotedColumns {
+			sourceColumns[i] = "source." + column
+		}
+		conditions := make([]string, len(quotedConflict))
+		for i, column := range quotedConflict {
+			conditions[i] = fmt.Sprintf("target.%s = source.%s", column, column)
+		}
+		matched := ""
+		if !stmt.doNothing {
+			condition := ""
+			if stmt.skipsDeleted() {
+				condition = " AND target." + stmt.softDelete.notDeletedCondition(stmt.driverName)
+			}
+			matched = " WHEN MATCHED" + condition + " THEN UPDATE SET " + strings.Join(stmt.setClauses("target.", func(column string) string {
+				return "source." + column
+			}), ", ")
+		}
+		query = fmt.Sprintf("MERGE INTO %s WITH (HOLDLOCK) AS target USING (VALUES (%s)) AS source (%s) ON %s%s WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s) OUTPUT INSERTED.%s;",
+			quotedTableName,
+			strings.Join(placeholders, ", "),
+			strings.Join(quotedColumns, ", "),
+			strings.Join(conditions, " AND "),
+			matched,
+			strings.Join(quotedColumns, ", "),
+			strings.Join(sourceColumns, ", "),
+			quotedPK)
+	default:
+		action := "DO NOTHING"
+		if !stmt.doNothing {
+			action = "DO UPDATE SET " + strings.Join(stmt.setClauses("", func(column string) string {
+				return "EXCLUDED." + column
+			}), ", ")
+			if stmt.skipsDeleted() {
+				action += " WHERE " + quotedTableName + "." + stmt.softDelete.notDeletedCondition(stmt.driverName)
+			}
+		}
+		query = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) %s RETURNING %s",
+			quotedTableName,
+			strings.Join(quotedColumns, ", "),
+			strings.Join(placeholders, ", "),
+			strings.Join(quotedConflict, ", "),
+			action,
+			quotedPK)
+	}
+
+	rows, err := exec.QueryAll(ctx, query, stmt.values...)
+	if err != nil {
+		return fmt.Errorf("typedb: Upsert failed: %w", err)
+	}
+	if len(rows) == 0 {
+		// Do-nothing mode hit an existing row, or a soft-deleted row was skipped
+		return nil
+	}
+
+	idValue, ok := rows[0][stmt.primaryKeyColumn]
+	if !ok {
+		idValue, ok = rows[0][strings.ToUpper(stmt.primaryKeyColumn)]
+		if !ok {
+			return fmt.Errorf("typedb: Upsert RETURNING clause did not return primary key column %s", stmt.primaryKeyColumn)
+		}
+	}
+	return setFieldValue(model, primaryField.Name, idValue)
+}
+
+// upsertMySQL upserts with INSERT ... ON DUPLICATE KEY UPDATE. An integer primary key is assigned
+// through LAST_INSERT_ID(pk) on update, so LastInsertId returns it for both inserted and updated rows;
+// other primary keys are selected by the conflict columns afterwards.
+func upsertMySQL[T ModelInterface](ctx context.Context, exec Executor, model T, stmt upsertStatement, primaryField *reflect.StructField) error {
+	quotedTableName, quotedColumns, placeholders := buildInsertQueryParts(stmt.driverName, stmt.tableName, stmt.columns, stmt.values)
+	quotedPK := quoteIdentifier(stmt.driverName, stmt.primaryKeyColumn)
+
+	// LAST_INSERT_ID(expr) only carries integers; other keys are read back by the conflict columns
+	autoIncrement := isIntegerKind(validateValueType(primaryField.Type).Kind())
+	clauses := []string{fmt.Sprintf("%s = %s", quotedPK, quotedPK)}
+	if autoIncrement {
+		clauses[0] = fmt.Sprintf("%s = LAST_INSERT_ID(%s)", quotedPK, quotedPK)
+	}
+	if !stmt.doNothing && stmt.hasUpdates() {
+		updates := stmt.setClauses("", func(column string) string {
+			return "VALUES(" + column + ")"
+		})
+		if stmt.skipsDeleted() {
+			// ON DUPLICATE KEY UPDATE has no WHERE: soft-deleted rows keep their values
+			notDeleted := stmt.softDelete.notDeletedCondition(stmt.driverName)
+			for i, clause := range updates {
+				column, value, _ := strings.Cut(clause, " = ")
+				updates[i] = fmt.Sprintf("%s = IF(%s, %s, %s)", column, notDeleted, value, column)
+			}
+		}
+		clauses = append(clauses, updates...)
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE %s",
+		quotedTableName,
+		strings.Join(quotedColumns, ", "),
+		strings.Join(placeholders, ", "),
+		strings.Join(clauses, ", "))
+
+	result, err := exec.Exec(ctx, query, stmt.values...)
+	if err != nil {
+		return fmt.Errorf("typedb: Upsert failed: %w", err)
+	}
+	if !autoIncrement {
+		return selectUpsertPrimaryKey(ctx, exec, model, stmt, primaryField, quotedTableName)
+	}
+	if result == nil {
+		return fmt.Errorf("typedb: Upsert returned nil result")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("typedb: Upsert failed to get last insert ID: %w", err)
+	}
+	if id == 0 {
+		// No AUTO_INCREMENT value was generated or assigned; the primary key was inserted as set
+		return nil
+	}
+	return setFieldValue(model, primaryField.Name, id)
+}
+
+// upsertOracle upserts with MERGE, which cannot return values. Unless the primary key is the conflict
+// target, it is read afterward by the conflict columns.
+func upsertOracle[T ModelInterface](ctx context.Context, exec Executor, model T, stmt upsertStatement, primaryField *reflect.StructField) error {
+	quotedTableName, quotedColumns, placeholders := buildInsertQueryParts(stmt.driverName, stmt.tableName, stmt.columns, stmt.values)
+
+	selectColumns := make([]string, len(quotedColumns))
+	sourceColumns := make([]string, len(quotedColumns))
+	for i, column := range quotedColumns {
+		selectColumns[i] = placeholders[i] + " AS " + column
+		sourceColumns[i] = "source." + column
+	}
+	conditions := make([]string, len(stmt.conflictColumns))
+	for i, column := range stmt.conflictColumns {
+		quoted := quoteIdentifier(stmt.driverName, column)
+		conditions[i] = fmt.Sprintf("target.%s = source.%s", quoted, quoted)
+	}
+
+	// Oracle cannot update columns referenced in the ON clause, so there is no self-assignment fallback
+	matched := ""
+	if !stmt.doNothing && stmt.hasUpdates() {
+		matched = " WHEN MATCHED THEN UPDATE SET " + strings.Join(stmt.setClauses("target.", func(column string) string {
+			return "source." + column
+		}), ", ")
+		if stmt.skipsDeleted() {
+			matched += " WHERE target." + stmt.softDelete.notDeletedCondition(stmt.driverName)
+		}
+	}
+	query := fmt.Sprintf("MERGE INTO %s target USING (SELECT %s FROM DUAL) source ON (%s)%s WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)",
+		quotedTableName,
+		strings.Join(selectColumns, ", "),
+		strings.Join(conditions, " AND "),
+		matched,
+		strings.Join(quotedColumns, ", "),
+		strings.Join(sourceColumns, ", "))
+
+	if _, err := exec.Exec(ctx, query, stmt.values...); err != nil {
+		return fmt.Errorf("typedb: Upsert failed: %w", err)
+	}
+	return selectUpsertPrimaryKey(ctx, exec, model, stmt, primaryField, quotedTableName)
+}
+
+// selectUpsertPrimaryKey reads the primary key of the upserted row by the conflict columns, for
+// drivers that cannot return it from the upsert statement. Does nothing when the primary key is
+// the conflict target, since it was inserted as set.
+func selectUpsertPrimaryKey[T ModelInterface](ctx context.Context, exec Executor, model T, stmt upsertStatement, primaryField *reflect.StructField, quotedTableName string) error {
+	if len(stmt.conflictColumns) == 1 && stmt.conflictColumns[0] == stmt.primaryKeyColumn {
+		return nil
+	}
+
+	// Bind the conflict values from their inserted positions, keeping their log masking
+	conditions := make([]string, len(stmt.conflictColumns))
+	args := make([]any, len(stmt.conflictColumns))
+	var maskIndices []int
+	insertMask, _ := getMaskIndices(ctx)
+	for i, column := range stmt.conflictColumns {
+		quoted := quoteIdentifier(stmt.driverName, column)
+		conditions[i] = fmt.Sprintf("%s = %s", quoted, generatePlaceholder(stmt.driverName, i+1))
+		position := slices.Index(stmt.columns, column)
+		args[i] = stmt.values[position]
+		if slices.Contains(insertMask, position) {
+			maskIndices = append(maskIndices, i)
+		}
+	}
+	selectQuery := fmt.Sprintf("SELECT %s FROM %s WHERE %s",
+		quoteIdentifier(stmt.driverName, stmt.primaryKeyColumn),
+		quotedTableName,
+		strings.Join(conditions, " AND "))
+
+	row, err := exec.QueryRowMap(WithMaskIndices(ctx, maskIndices), selectQuery, args...)
+	if err != nil {
+		return fmt.Errorf("typedb: Upsert failed to read primary key: %w", err)
+	}
+	idValue, ok := row[strings.ToUpper(stmt.primaryKeyColumn)]
+	if !ok {
+		idValue, ok = row[stmt.primaryKeyColumn]
+		if !ok {
+			return fmt.Errorf("typedb: Upsert did not return primary key column %s", stmt.primaryKeyColumn)
+		}
+	}
+	return setFieldValue(model, primaryField.Name, idValue)
+}
+
+// isIntegerKind reports whether kind is a signed or unsigned integer kind.
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,455 @@
+package typedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// UpsertTestUser is a test model for Upsert
+type UpsertTestUser struct {
+	Model
+	CreatedAt string `db:"created_at" dbUpdate:"false"`
+	UpdatedAt string `db:"updated_at" dbInsert:"false" dbUpdate:"auto-timestamp"`
+	Email     string `db:"email" load:"unique"`
+	Name      string `db:"name"`
+	Token     string `db:"token" nolog:"true"`
+	ID        int64  `db:"id" load:"primary"`
+}
+
+func (u *UpsertTestUser) TableName() string {
+	return "users"
+}
+
+func (u *UpsertTestUser) QueryByID() string {
+	return "SELECT id, email, name, token, created_at, updated_at FROM users WHERE id = ?"
+}
+
+func (u *UpsertTestUser) QueryByEmail() string {
+	return "SELECT id, email, name, token, created_at, updated_at FROM users WHERE email = ?"
+}
+
+// UpsertTestCode is a test model with a string primary key
+type UpsertTestCode struct {
+	Model
+	Code string `db:"code" load:"primary"`
+	Slug string `db:"slug" load:"unique"`
+	Name string `db:"name"`
+}
+
+func (c *UpsertTestCode) TableName() string {
+	return "codes"
+}
+
+func (c *UpsertTestCode) QueryByCode() string {
+	return "SELECT code, slug, name FROM codes WHERE code = ?"
+}
+
+func (c *UpsertTestCode) QueryBySlug() string {
+	return "SELECT code, slug, name FROM codes WHERE slug = ?"
+}
+
+func TestUpsert_SQLite(t *testing.T) {
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT UNIQUE, name TEXT, token TEXT, created_at TEXT, updated_at TEXT)"); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+
+	user := &UpsertTestUser{Email: "a@example.com", Name: "Ann", CreatedAt: "2024-01-01"}
+	if err := Upsert(ctx, db, user); err != nil {
+		t.Fatalf("Upsert insert failed: %v", err)
+	}
+	if user.ID != 1 {
+		t.Errorf("Expected ID 1 after insert, got %d", user.ID)
+	}
+
+	again := &UpsertTestUser{Email: "a@example.com", Name: "Annie", CreatedAt: "2030-01-01"}
+	if err := Upsert(ctx, db, again, "Email"); err != nil {
+		t.Fatalf("Upsert update failed: %v", err)
+	}
+	if again.ID != 1 {
+		t.Errorf("Expected the existing ID 1 after update, got %d", again.ID)
+	}
+	loaded := &UpsertTestUser{ID: 1}
+	if err := Load(ctx, db, loaded); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Name != "Annie" || loaded.CreatedAt != "2024-01-01" || loaded.UpdatedAt == "" {
+		t.Errorf("Expected name updated, created_at kept and updated_at set, got %+v", loaded)
+	}
+
+	if err := Upsert(ctx, db, &UpsertTestUser{ID: 1, Email: "a@example.com", Name: "By ID"}); err != nil {
+		t.Errorf("Upsert by primary key failed: %v", err)
+	}
+
+	ignored := &UpsertTestUser{Email: "a@example.com", Name: "Ignored"}
+	if err := Upsert(WithUpsertDoNothing(ctx), db, ignored); err != nil {
+		t.Errorf("Upsert do nothing failed: %v", err)
+	}
+	if ignored.ID != 0 {
+		t.Errorf("Expected ID to stay unset when nothing was inserted, got %d", ignored.ID)
+	}
+	inserted := &UpsertTestUser{Email: "b@example.com", Name: "Bob"}
+	if err := Upsert(WithUpsertDoNothing(ctx), db, inserted); err != nil || inserted.ID != 2 {
+		t.Errorf("Expected do nothing mode to insert a new row with ID 2, got %d, %v", inserted.ID, err)
+	}
+
+	row, err := db.QueryRowMap(ctx, "SELECT COUNT(*) AS n, MIN(name) AS name FROM users WHERE email = 'a@example.com'")
+	if err != nil || row["n"] != int64(1) || row["name"] != "By ID" {
+		t.Errorf("Expected a single row named By ID, got %v, %v", row, err)
+	}
+}
+
+func TestUpsert_Errors(t *testing.T) {
+	ctx := context.Background()
+	exec := &MockExecutor{}
+
+	if err := Upsert(ctx, exec, &UpsertTestUser{Name: "No key"}); err == nil || !strings.Contains(err.Error(), "requires conflict fields") {
+		t.Errorf("Expected missing conflict target error, got %v", err)
+	}
+	if err := Upsert(ctx, exec, &UpsertTestUser{Email: "a@example.com"}, "Missing"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("Expected ErrFieldNotFound, got %v", err)
+	}
+	if err := Upsert(ctx, exec, &UpsertTestUser{Email: "a@example.com"}, "Name"); err == nil || !strings.Contains(err.Error(), "conflict field Name is not set") {
+		t.Errorf("Expected unset conflict field error, got %v", err)
+	}
+	if err := Upsert(ctx, exec, &UpsertTestUser{Email: "a@example.com", UpdatedAt: "now"}, "UpdatedAt"); err == nil || !strings.Contains(err.Error(), "not inserted") {
+		t.Errorf("Expected dbInsert:\"false\" conflict column error, got %v", err)
+	}
+}
+
+func TestUpsert_QueriesPerDriver(t *testing.T) {
+	tests := []struct {
+		driver string
+		query  string
+		lookup string
+	}{
+		{
+			driver: "postgres",
+			query:  `INSERT INTO "users" ("email", "name", "token") VALUES ($1, $2, $3) ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name", "token" = EXCLUDED."token", "updated_at" = CURRENT_TIMESTAMP RETURNING "id"`,
+		},
+		{
+			driver: "mysql",
+			query:  "INSERT INTO `users` (`email`, `name`, `token`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `id` = LAST_INSERT_ID(`id`), `name` = VALUES(`name`), `token` = VALUES(`token`), `updated_at` = NOW()",
+		},
+		{
+			driver: "sqlserver",
+			query:  "MERGE INTO [users] WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2, @p3)) AS source ([email], [name], [token]) ON target.[email] = source.[email] WHEN MATCHED THEN UPDATE SET target.[name] = source.[name], target.[token] = source.[token], target.[updated_at] = GETDATE() WHEN NOT MATCHED THEN INSERT ([email], [name], [token]) VALUES (source.[email], source.[name], source.[token]) OUTPUT INSERTED.[id];",
+		},
+		{
+			driver: "oracle",
+			query:  `MERGE INTO "USERS" target USING (SELECT :1 AS "EMAIL", :2 AS "NAME", :3 AS "TOKEN" FROM DUAL) source ON (target."EMAIL" = source."EMAIL") WHEN MATCHED THEN UPDATE SET target."NAME" = source."NAME", target."TOKEN" = source."TOKEN", target."UPDATED_AT" = CURRENT_TIMESTAMP WHEN NOT MATCHED THEN INSERT ("EMAIL", "NAME", "TOKEN") VALUES (source."EMAIL", source."NAME", source."TOKEN")`,
+			lookup: `SELECT "ID" FROM "USERS" WHERE "EMAIL" = :1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock: %v", err)
+			}
+			defer sqlDB.Close()
+
+			logger := &testLogger{}
+			db := NewDBWithLogger(sqlDB, tt.driver, 5*time.Second, logger)
+			switch {
+			case tt.driver == "mysql":
+				mock.ExpectExec(regexp.QuoteMeta(tt.query)).WithArgs("a@example.com", "Ann", "secret").WillReturnResult(sqlmock.NewResult(5, 1))
+			case tt.lookup != "":
+				mock.ExpectExec(regexp.QuoteMeta(tt.query)).WithArgs("a@example.com", "Ann", "secret").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(tt.lookup)).WithArgs("a@example.com").WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(int64(5)))
+			default:
+				mock.ExpectQuery(regexp.QuoteMeta(tt.query)).WithArgs("a@example.com", "Ann", "secret").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+			}
+
+			user := &UpsertTestUser{Email: "a@example.com", Name: "Ann", Token: "secret"}
+			if err := Upsert(context.Background(), db, user); err != nil {
+				t.Fatalf("Upsert failed: %v", err)
+			}
+			if user.ID != 5 {
+				t.Errorf("Expected ID 5, got %d", user.ID)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unmet expectations: %v", err)
+			}
+			if logged := fmt.Sprint(logger.debugs); strings.Contains(logged, "secret") {
+				t.Errorf("Expected nolog values to be masked, got %s", logged)
+			}
+		})
+	}
+}
+
+func TestUpsert_MySQLStringPrimaryKey(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `codes` (`slug`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `code` = `code`, `name` = VALUES(`name`)")).
+		WithArgs("launch", "Launch").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `code` FROM `codes` WHERE `slug` = ?")).
+		WithArgs("launch").WillReturnRows(sqlmock.NewRows([]string{"code"}).AddRow("c-42"))
+
+	db := NewDB(sqlDB, "mysql", 5*time.Second)
+	code := &UpsertTestCode{Slug: "launch", Name: "Launch"}
+	if err := Upsert(context.Background(), db, code); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if code.Code != "c-42" {
+		t.Errorf("Expected the primary key read back by slug, got %q", code.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unmet expectations: %v", err)
+	}
+}
+
+func TestUpsert_DoNothingQueries(t *testing.T) {
+	tests := []struct {
+		driver string
+		query  string
+	}{
+		{driver: "postgres", query: `INSERT INTO "users" ("id", "email") VALUES ($1, $2) ON CONFLICT ("id") DO NOTHING RETURNING "id"`},
+		{driver: "mysql", query: "INSERT INTO `users` (`id`, `email`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `id` = LAST_INSERT_ID(`id`)"},
+		{driver: "sqlserver", query: "MERGE INTO [users] WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2)) AS source ([id], [email]) ON target.[id] = source.[id] WHEN NOT MATCHED THEN INSERT ([id], [email]) VALUES (source.[id], source.[email]) OUTPUT INSERTED.[id];"},
+		{driver: "oracle", query: `MERGE INTO "USERS" target USING (SELECT :1 AS "ID", :2 AS "EMAIL" FROM DUAL) source ON (target."ID" = source."ID") WHEN NOT MATCHED THEN INSERT ("ID", "EMAIL") VALUES (source."ID", source."EMAIL")`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock: %v", err)
+			}
+			defer sqlDB.Close()
+
+			db := NewDB(sqlDB, tt.driver, 5*time.Second)
+			switch tt.driver {
+			case "mysql", "oracle":
+				mock.ExpectExec(regexp.QuoteMeta(tt.query)).WithArgs(int64(9), "a@example.com").WillReturnResult(sqlmock.NewResult(0, 0))
+			default:
+				mock.ExpectQuery(regexp.QuoteMeta(tt.query)).WithArgs(int64(9), "a@example.com").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			}
+
+			user := &UpsertTestUser{ID: 9, Email: "a@example.com"}
+			if err := Upsert(WithUpsertDoNothing(context.Background()), db, user); err != nil {
+				t.Fatalf("Upsert failed: %v", err)
+			}
+			if user.ID != 9 {
+				t.Errorf("Expected ID to stay 9, got %d", user.ID)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+// UpsertTestMember is a test model with hooks and a soft delete column
+type UpsertTestMember struct {
+	Model
+	DeletedAt *time.Time `db:"deleted_at" dbDelete:"soft-timestamp"`
+	Email     string     `db:"email" load:"unique"`
+	Name      string     `db:"name"`
+	hooks     []string
+	ID        int64 `db:"id" load:"primary"`
+}
+
+func (m *UpsertTestMember) TableName() string {
+	return "members"
+}
+
+func (m *UpsertTestMember) QueryByID() string {
+	return "SELECT id, email, name, deleted_at FROM members WHERE id = ?"
+}
+
+func (m *UpsertTestMember) BeforeInsert(ctx context.Context, exec Executor) error {
+	m.hooks = append(m.hooks, "BeforeInsert")
+	m.Email = strings.ToLower(strings.TrimSpace(m.Email))
+	return nil
+}
+
+func (m *UpsertTestMember) BeforeUpdate(ctx context.Context, exec Executor) error {
+	m.hooks = append(m.hooks, "BeforeUpdate")
+	return nil
+}
+
+func (m *UpsertTestMember) AfterInsert(ctx context.Context, exec Executor) error {
+	m.hooks = append(m.hooks, "AfterInsert")
+	return nil
+}
+
+func (m *UpsertTestMember) AfterUpdate(ctx context.Context, exec Executor) error {
+	m.hooks = append(m.hooks, "AfterUpdate")
+	return nil
+}
+
+func openUpsertMembers(t *testing.T) *DB {
+	t.Helper()
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithMaxOpenConns(1))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := db.Exec(context.Background(), "CREATE TABLE members (id INTEGER PRIMARY KEY, email TEXT UNIQUE, name TEXT, deleted_at TIMESTAMP)"); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+	return db
+}
+
+func TestUpsert_Hooks(t *testing.T) {
+	db := openUpsertMembers(t)
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	member := &UpsertTestMember{Email: "Ann@example.com", Name: "Ann"}
+	if err := Upsert(ctx, db, member, "Email"); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	want := []string{"BeforeInsert", "BeforeUpdate", "AfterInsert", "AfterUpdate"}
+	if strings.Join(member.hooks, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected hooks %v, got %v", want, member.hooks)
+	}
+
+	// The normalized conflict value matches the existing row
+	again := &UpsertTestMember{Email: " ANN@example.com", Name: "Annie"}
+	if err := Upsert(ctx, db, again, "Email"); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if again.ID != member.ID {
+		t.Errorf("Expected the normalized email to update row %d, got %d", member.ID, again.ID)
+	}
+
+	ignored := &UpsertTestMember{Email: "ann@example.com", Name: "Ignored"}
+	if err := Upsert(WithUpsertDoNothing(ctx), db, ignored, "Email"); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if strings.Join(ignored.hooks, ",") != "BeforeInsert,AfterInsert" {
+		t.Errorf("Expected only insert hooks with WithUpsertDoNothing, got %v", ignored.hooks)
+	}
+}
+
+func TestUpsert_SoftDeletedRow(t *testing.T) {
+	db := openUpsertMembers(t)
+	defer closeDB(t, db)
+
+	ctx := context.Background()
+	member := &UpsertTestMember{Email: "ann@example.com", Name: "Ann"}
+	if err := Upsert(ctx, db, member, "Email"); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	t.Run("restores by default", func(t *testing.T) {
+		if err := Delete(ctx, db, &UpsertTestMember{ID: member.ID}); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		restored := &UpsertTestMember{Email: "ann@example.com", Name: "Restored"}
+		if err := Upsert(ctx, db, restored, "Email"); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+		if restored.ID != member.ID {
+			t.Errorf("Expected the soft-deleted row %d to be reused, got %d", member.ID, restored.ID)
+		}
+		loaded := &UpsertTestMember{ID: member.ID}
+		if err := Load(ctx, db, loaded); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if loaded.DeletedAt != nil || loaded.Name != "Restored" {
+			t.Errorf("Expected the row restored and updated, got %+v", loaded)
+		}
+	})
+
+	t.Run("skips with WithUpsertSkipDeleted", func(t *testing.T) {
+		if err := Delete(ctx, db, &UpsertTestMember{ID: member.ID}); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		skipped := &UpsertTestMember{Email: "ann@example.com", Name: "Skipped"}
+		if err := Upsert(WithUpsertSkipDeleted(ctx), db, skipped, "Email"); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+		if skipped.ID != 0 {
+			t.Errorf("Expected no primary key for a skipped row, got %d", skipped.ID)
+		}
+		loaded := &UpsertTestMember{ID: member.ID}
+		if err := Load(ctx, db, loaded); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if loaded.DeletedAt == nil || loaded.Name != "Restored" {
+			t.Errorf("Expected the soft-deleted row unchanged, got %+v", loaded)
+		}
+	})
+}
+
+func TestUpsert_SoftDeleteQueries(t *testing.T) {
+	tests := []struct {
+		driver  string
+		restore string
+		skip    string
+	}{
+		{
+			driver:  "postgres",
+			restore: `INSERT INTO "members" ("email", "name") VALUES ($1, $2) ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name", "deleted_at" = NULL RETURNING "id"`,
+			skip:    `INSERT INTO "members" ("email", "name") VALUES ($1, $2) ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name" WHERE "members"."deleted_at" IS NULL RETURNING "id"`,
+		},
+		{
+			driver:  "mysql",
+			restore: "INSERT INTO `members` (`email`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `id` = LAST_INSERT_ID(`id`), `name` = VALUES(`name`), `deleted_at` = NULL",
+			skip:    "INSERT INTO `members` (`email`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `id` = LAST_INSERT_ID(`id`), `name` = IF(`deleted_at` IS NULL, VALUES(`name`), `name`)",
+		},
+		{
+			driver:  "sqlserver",
+			restore: "MERGE INTO [members] WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2)) AS source ([email], [name]) ON target.[email] = source.[email] WHEN MATCHED THEN UPDATE SET target.[name] = source.[name], target.[deleted_at] = NULL WHEN NOT MATCHED",
+			skip:    "MERGE INTO [members] WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2)) AS source ([email], [name]) ON target.[email] = source.[email] WHEN MATCHED AND target.[deleted_at] IS NULL THEN UPDATE SET target.[name] = source.[name] WHEN NOT MATCHED",
+		},
+		{
+			driver:  "oracle",
+			restore: `WHEN MATCHED THEN UPDATE SET target."NAME" = source."NAME", target."DELETED_AT" = NULL WHEN NOT MATCHED`,
+			skip:    `WHEN MATCHED THEN UPDATE SET target."NAME" = source."NAME" WHERE target."DELETED_AT" IS NULL WHEN NOT MATCHED`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			for _, skip := range []bool{false, true} {
+				sqlDB, mock, err := sqlmock.New()
+				if err != nil {
+					t.Fatalf("Failed to create mock: %v", err)
+				}
+
+				query := tt.restore
+				ctx := context.Background()
+				if skip {
+					query = tt.skip
+					ctx = WithUpsertSkipDeleted(ctx)
+				}
+				switch tt.driver {
+				case "mysql":
+					mock.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(3, 2))
+				case "oracle":
+					mock.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(int64(3)))
+				default:
+					mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(3)))
+				}
+
+				db := NewDB(sqlDB, tt.driver, 5*time.Second)
+				if err := Upsert(ctx, db, &UpsertTestMember{Email: "ann@example.com", Name: "Ann"}, "Email"); err != nil {
+					t.Errorf("Upsert (skip %v) failed: %v", skip, err)
+				}
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("Unmet expectations (skip %v): %v", skip, err)
+				}
+				sqlDB.Close()
+			}
+		})
+	}
+}
@@ -46,6 +46,8 @@ func (ve *ValidationErrors) Error() string {
 // - Fields with load:"unique" must have QueryBy{Field}() method
 // - Fields with load:"composite:name" must have QueryBy{Field1}{Field2}...() method (fields sorted alphabetically)
 // - Query methods return string
+// - Fields with rel tags are valid relations whose model has the QueryBy{Key}s() method
+// - validate tags use known rules with valid parameters for the field type
 func ValidateModel[T ModelInterface](model T) error {
 	t := reflect.TypeOf(model)
 	if t.Kind() != reflect.Ptr {
@@ -110,6 +112,25 @@ func ValidateModel[T ModelInterface](model T) error {
 		}
 	}
 
+	// Validate the db:"*" field used by ColumnPolicyCollect
+	errors = append(errors, validateCollectField(t)...)
+
+	// Validate dbType tags
+	errors = append(errors, validateDBTypeFields(t)...)
+	errors = append(errors, validateTimeFormatFields(t)...)
+
+	// Validate slice and map fields can be encoded on every driver
+	errors = append(errors, validateCollectionFields(t, "", nil)...)
+
+	// Validate rel tags and the related models' query methods
+	errors = append(errors, validateRelationFields(t)...)
+
+	// Validate validate tag syntax and rules
+	errors = append(errors, validateValidateTags(t)...)
+
+	// Validate dbDelete tags
+	errors = append(errors, validateSoftDeleteFields(t)...)
+
 	if len(errors) > 0 {
 		return &ValidationError{
 			ModelName: t.Name(),
@@ -0,0 +1,380 @@
+package typedb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// queryMethodSpec describes a QueryBy* method and the number of key values it is called with.
+type queryMethodSpec struct {
+	methodName string
+	keyCount   int
+}
+
+// ValidateQueries checks every registered model's QueryBy* queries against a live database.
+// For each query it:
+//   - Checks the placeholder count matches the number of key fields passed by Load/LoadByField/LoadByComposite
+//   - Prepares the statement, so syntax errors and unknown tables or columns are reported
+//   - Executes it with NULL keys (returning no rows) and checks the returned columns against the db tags
+//
+// Returned columns that map to no db tag and db-tagged fields the query never returns are checked
+// against the column policy of the model (or of db): they are errors when the policy would reject
+// them at runtime (ColumnPolicyErrorOnUnknown without ColumnPolicyCollect, ColumnPolicyErrorOnMissing),
+// and are logged as warnings otherwise.
+// Returns ValidationErrors containing all failures grouped by model.
+//
+// Example:
+//
+//	if err := typedb.ValidateQueries(ctx, db); err != nil {
+//	    log.Fatal(err)
+//	}
+func ValidateQueries(ctx context.Context, db *DB) error {
+	var validationErrors []*ValidationError
+
+	for _, modelType := range GetRegisteredModels() {
+		if ve := validateModelQueries(ctx, db, modelType); ve != nil {
+			validationErrors = append(validationErrors, ve)
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return &ValidationErrors{
+			Errors: validationErrors,
+		}
+	}
+
+	return nil
+}
+
+// validateModelQueries validates the QueryBy* queries of a single model type.
+// Returns nil if all queries are valid.
+func validateModelQueries(ctx context.Context, db *DB, modelType reflect.Type) *ValidationError {
+	model := reflect.New(modelType).Interface()
+	logger := db.getLogger()
+
+	tags := collectDBTags(modelType)
+	policy := resolveColumnPolicy(GetModelOptions(modelType).ColumnPolicy, db.columnPolicy)
+	rejectUnknown := policy.has(ColumnPolicyErrorOnUnknown) && !policy.has(ColumnPolicyCollect)
+
+	var errors []string
+	for _, spec := range collectQueryMethodSpecs(modelType) {
+		if _, found := findMethod(model, spec.methodName); !found {
+			// Missing methods are reported by ValidateModel
+			continue
+		}
+		results := reflect.ValueOf(model).MethodByName(spec.methodName).Call(nil)
+		if len(results) != 1 || results[0].Kind() != reflect.String {
+			continue
+		}
+		query := results[0].String()
+
+		if count := countPlaceholders(query); count != spec.keyCount {
+			errors = append(errors, fmt.Sprintf("%s(): query has %d placeholder(s), expected %d", spec.methodName, count, spec.keyCount))
+			continue
+		}
+
+		columns, err := queryColumns(ctx, db, query, spec.keyCount)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("%s(): %v", spec.methodName, err))
+			continue
+		}
+
+		returned := make(map[string]bool, len(columns))
+		nested := nestedQueryColumns(db, modelType, columns)
+		var unknown []string
+		for _, col := range columns {
+			colKey := strings.ToLower(col)
+			returned[colKey] = true
+			if nestedTag, ok := nested[colKey]; ok {
+				returned[nestedTag] = true
+				continue
+			}
+			if tags[colKey] {
+				continue
+			}
+			if rejectUnknown {
+				errors = append(errors, fmt.Sprintf("%s(): column %q does not map to any db tag", spec.methodName, col))
+			} else {
+				unknown = append(unknown, col)
+			}
+		}
+		if len(unknown) > 0 {
+			logger.Warn("Query returns columns that do not map to any db tag", "model", modelType.Name(), "method", spec.methodName, "unknown", unknown)
+		}
+
+		var missing []string
+		for tag := range tags {
+			if !returned[tag] {
+				missing = append(missing, tag)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			if policy.has(ColumnPolicyErrorOnMissing) {
+				errors = append(errors, fmt.Sprintf("%s(): query does not return db tag(s) %s", spec.methodName, strings.Join(missing, ", ")))
+				continue
+			}
+			logger.Warn("Query does not return all db-tagged fields", "model", modelType.Name(), "method", spec.methodName, "missing", missing)
+		}
+	}
+
+	if len(errors) > 0 {
+		return &ValidationError{
+			ModelName: modelType.Name(),
+			Errors:    errors,
+		}
+	}
+	return nil
+}
+
+// collectQueryMethodSpecs lists the QueryBy* methods implied by a model's load tags.
+func collectQueryMethodSpecs(modelType reflect.Type) []queryMethodSpec {
+	var primaryFields []*reflect.StructField
+	uniqueFields := make(map[string]*reflect.StructField)
+	compositeGroups := make(map[string][]*reflect.StructField)
+	collectLoadFields(modelType, &primaryFields, uniqueFields, compositeGroups)
+
+	var specs []queryMethodSpec
+	for _, field := range primaryFields {
+		specs = append(specs, queryMethodSpec{methodName: "QueryBy" + field.Name, keyCount: 1})
+	}
+
+	uniqueNames := make([]string, 0, len(uniqueFields))
+	for name := range uniqueFields {
+		uniqueNames = append(uniqueNames, name)
+	}
+	sort.Strings(uniqueNames)
+	for _, name := range uniqueNames {
+		specs = append(specs, queryMethodSpec{methodName: "QueryBy" + name, keyCount: 1})
+	}
+
+	compositeNames := make([]string, 0, len(compositeGroups))
+	for name := range compositeGroups {
+		compositeNames = append(compositeNames, name)
+	}
+	sort.Strings(compositeNames)
+	for _, name := range compositeNames {
+		fields := compositeGroups[name]
+		if len(fields) < 2 {
+			continue
+		}
+		fieldNames := make([]string, len(fields))
+		for i, f := range fields {
+			fieldNames[i] = f.Name
+		}
+		sort.Strings(fieldNames)
+		specs = append(specs, queryMethodSpec{methodName: "QueryBy" + strings.Join(fieldNames, ""), keyCount: len(fields)})
+	}
+
+	return specs
+}
+
+// collectDBTags returns the set of db tags on a struct type, including embedded structs.
+func collectDBTags(t reflect.Type) map[string]bool {
+	tags := make(map[string]bool)
+
+	var collect func(reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				embeddedType := field.Type
+				if embeddedType.Kind() == reflect.Ptr {
+					embeddedType = embeddedType.Elem()
+				}
+				if embeddedType.Kind() == reflect.Struct {
+					collect(embeddedType)
+					continue
+				}
+			}
+			dbTag := field.Tag.Get("db")
+			if dbTag == "" || dbTag == "-" || dbTag == collectColumnsTag {
+				continue
+			}
+			tags[dbTag] = true
+		}
+	}
+
+	collect(t)
+	return tags
+}
+
+// nestedQueryColumns maps the columns that hydrate nested struct fields of modelType to the tag of
+// their field, following the prefix rules of hydrateNestedStructs.
+func nestedQueryColumns(db *DB, modelType reflect.Type, columns []string) map[string]string {
+	row := make(map[string]any, len(columns))
+	for _, col := range columns {
+		row[strings.ToLower(col)] = nil
+	}
+
+	nested := make(map[string]string)
+	for _, field := range nestedStructFields(modelType) {
+		if _, ok := row[field.tag]; ok {
+			continue
+		}
+		if _, hasCodec := lookupCodec(db.codecs, field.structType); hasCodec {
+			continue
+		}
+		_, consumed := nestedColumns(row, field)
+		for _, key := range consumed {
+			nested[key] = field.tag
+		}
+	}
+	return nested
+}
+
+// queryColumns prepares and runs query with NULL arguments and returns its result columns.
+// Comparing a key column to NULL matches no rows, so no data is read.
+func queryColumns(ctx context.Context, db *DB, query string, argCount int) ([]string, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+
+	stmt, err := db.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare failed: %w", err)
+	}
+	defer func() {
+		if closeErr := stmt.Close(); closeErr != nil {
+			db.getLogger().Error("Failed to close statement", "error", closeErr)
+		}
+	}()
+
+	args := make([]any, argCount)
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execution failed: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			db.getLogger().Error("Failed to close rows", "error", closeErr)
+		}
+	}()
+
+	return rows.Columns()
+}
+
+// countPlaceholders counts the distinct bind parameters in a query, ignoring string literals,
+// quoted identifiers and comments. Positional placeholders ($1, @p1, :1) count up to the highest
+// position used; "?" placeholders are counted individually; named placeholders (:name) once per name.
+func countPlaceholders(query string) int {
+	questionMarks := 0
+	maxPosition := 0
+	named := make(map[string]bool)
+
+	for i := 0; i < len(query); i++ {
+		if end, ok := skipLiteral(query, i); ok {
+			i = end
+			continue
+		}
+		c := query[i]
+		switch {
+		case c == '?':
+			questionMarks++
+		case c == '$' || c == '@' || c == ':':
+			if c != '$' && i+1 < len(query) && query[i+1] == c {
+				// PostgreSQL type cast (::) or SQL Server system variable (@@)
+				i++
+				continue
+			}
+			start := i + 1
+			if c == '@' && start+1 < len(query) && (query[start] == 'p' || query[start] == 'P') && isDigit(query[start+1]) {
+				// SQL Server positional placeholder (@p1)
+				start++
+			}
+			end := start
+			for end < len(query) && isDigit(query[end]) {
+				end++
+			}
+			if end > start {
+				position := 0
+				for _, d := range query[start:end] {
+					position = position*10 + int(d-'0')
+				}
+				if position > maxPosition {
+					maxPosition = position
+				}
+				i = end - 1
+				continue
+			}
+			if c != '$' {
+				for end < len(query) && isIdentifierChar(query[end]) {
+					end++
+				}
+				if end > start {
+					named[query[start:end]] = true
+					i = end - 1
+				}
+			}
+		}
+	}
+
+	return questionMarks + maxPosition + len(named)
+}
+
+// skipLiteral reports whether a string literal, quoted identifier or comment starts at query[i],
+// and returns the index of its last byte (or len(query) if it is not terminated).
+func skipLiteral(query string, i int) (end int, ok bool) {
+	c := query[i]
+	switch {
+	case c == '\'' || c == '"' || c == '`':
+		// Doubled quotes are escapes
+		for i++; i < len(query); i++ {
+			if query[i] == c {
+				if i+1 < len(query) && query[i+1] == c {
+					i++
+					continue
+				}
+				return i, true
+			}
+		}
+		return len(query), true
+	case c == '-' && i+1 < len(query) && query[i+1] == '-':
+		for i < len(query) && query[i] != '\n' {
+			i++
+		}
+		return i, true
+	case c == '/' && i+1 < len(query) && query[i+1] == '*':
+		end := strings.Index(query[i+2:], "*/")
+		if end == -1 {
+			return len(query), true
+		}
+		return i + end + 3, true
+	default:
+		return i, false
+	}
+}
+
+// indexPlaceholder returns the index of the first occurrence of placeholder in query outside
+// string literals, quoted identifiers and comments, or -1.
+func indexPlaceholder(query, placeholder string) int {
+	for i := 0; i < len(query); i++ {
+		if end, ok := skipLiteral(query, i); ok {
+			i = end
+			continue
+		}
+		if !strings.HasPrefix(query[i:], placeholder) {
+			continue
+		}
+		if next := i + len(placeholder); next < len(query) && placeholder != "?" && isIdentifierChar(query[next]) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// isDigit reports whether c is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentifierChar reports whether c can appear in a named placeholder.
+func isIdentifierChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || isDigit(c)
+}
@@ -0,0 +1,263 @@
+package typedb
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// QueryValidUser is a model whose queries match the users table
+type QueryValidUser struct {
+	Model
+	Name   string `db:"name"`
+	Email  string `db:"email" load:"unique"`
+	ID     int64  `db:"id" load:"primary"`
+	OrgID  int64  `db:"org_id" load:"composite:org_email"`
+	Email2 string `db:"email2" load:"composite:org_email"`
+}
+
+func (u *QueryValidUser) QueryByID() string {
+	return "SELECT id, name, email, org_id, email2 FROM users WHERE id = ?"
+}
+
+func (u *QueryValidUser) QueryByEmail() string {
+	return "SELECT id, name, email, org_id, email2 FROM users WHERE email = ?"
+}
+
+func (u *QueryValidUser) QueryByEmail2OrgID() string {
+	return "SELECT id, name, email, org_id, email2 FROM users WHERE email2 = ? AND org_id = ?"
+}
+
+// QueryInvalidUser is a model with broken queries
+type QueryInvalidUser struct {
+	Model
+	Name  string `db:"name"`
+	Email string `db:"email" load:"unique"`
+	Nick  string `db:"nick"`
+	ID    int64  `db:"id" load:"primary"`
+}
+
+func (u *QueryInvalidUser) QueryByID() string {
+	return "SELECT id, name, email, org_id FROM users WHERE id = ?"
+}
+
+func (u *QueryInvalidUser) QueryByEmail() string {
+	return "SELECT id, name FROM users WHERE email = ? AND name = ?"
+}
+
+// QueryTypoUser is a model whose query references a column that does not exist
+type QueryTypoUser struct {
+	Model
+	Name string `db:"name"`
+	ID   int64  `db:"id" load:"primary"`
+}
+
+func (u *QueryTypoUser) QueryByID() string {
+	return "SELECT id, nmae FROM users WHERE id = ?"
+}
+
+// withRegisteredModels replaces the model registry for the duration of a test.
+func withRegisteredModels(t *testing.T, models ...reflect.Type) {
+	t.Helper()
+	registerMutex.Lock()
+	saved := registeredModels
+	registeredModels = models
+	registerMutex.Unlock()
+	t.Cleanup(func() {
+		registerMutex.Lock()
+		registeredModels = saved
+		registerMutex.Unlock()
+	})
+}
+
+// QueryNestedOrg is the nested struct of QueryNestedUser
+type QueryNestedOrg struct {
+	Name  string `db:"name"`
+	Email string `db:"email"`
+	ID    int64  `db:"id"`
+}
+
+// QueryNestedUser is a model whose query fills a nested struct from prefixed columns
+type QueryNestedUser struct {
+	Model
+	Org  QueryNestedOrg `db:"org"`
+	Name string         `db:"name"`
+	ID   int64          `db:"id" load:"primary"`
+}
+
+func (u *QueryNestedUser) QueryByID() string {
+	return `SELECT id, name, org_id, email2 AS org_name, email AS "org.email" FROM users WHERE id = ?`
+}
+
+const queryValidationSchema = "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, email TEXT, org_id INTEGER, email2 TEXT)"
+
+func TestValidateQueries_SQLite(t *testing.T) {
+	logger := &testLogger{}
+	db, err := OpenWithoutValidation("sqlite3", ":memory:", WithLogger(logger), WithMaxOpenConns(1), WithColumnPolicy(ColumnPolicyErrorOnUnknown))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Logf("Warning: failed to close database: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+	if _, err := db.Exec(ctx, queryValidationSchema); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	t.Run("valid model", func(t *testing.T) {
+		withRegisteredModels(t, reflect.TypeOf(QueryValidUser{}))
+		if err := ValidateQueries(ctx, db); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("invalid model", func(t *testing.T) {
+		withRegisteredModels(t, reflect.TypeOf(QueryValidUser{}), reflect.TypeOf(QueryInvalidUser{}), reflect.TypeOf(QueryTypoUser{}))
+		logger.warns = nil
+
+		err := ValidateQueries(ctx, db)
+		ves, ok := err.(*ValidationErrors)
+		if !ok {
+			t.Fatalf("Expected *ValidationErrors, got %T: %v", err, err)
+		}
+		if len(ves.Errors) != 2 {
+			t.Fatalf("Expected errors for 2 models, got %d: %v", len(ves.Errors), err)
+		}
+
+		msg := err.Error()
+		for _, want := range []string{
+			`QueryByID(): column "org_id" does not map to any db tag`,
+			"QueryByEmail(): query has 2 placeholder(s), expected 1",
+			"QueryTypoUser",
+			"prepare failed",
+		} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("Expected error to contain %q, got:\n%s", want, msg)
+			}
+		}
+
+		foundWarning := false
+		for _, entry := range logger.warns {
+			for i := 0; i < len(entry.keyvals)-1; i += 2 {
+				if entry.keyvals[i] == "missing" && reflect.DeepEqual(entry.keyvals[i+1], []string{"nick"}) {
+					foundWarning = true
+				}
+			}
+		}
+		if !foundWarning {
+			t.Errorf("Expected warning about unreturned nick field, got %+v", logger.warns)
+		}
+	})
+
+	t.Run("nested struct columns", func(t *testing.T) {
+		withRegisteredModels(t, reflect.TypeOf(QueryNestedUser{}))
+		logger.warns = nil
+
+		if err := ValidateQueries(ctx, db); err != nil {
+			t.Fatalf("Expected prefixed columns to map to the nested struct, got %v", err)
+		}
+		if len(logger.warns) != 0 {
+			t.Errorf("Expected no missing field warnings, got %+v", logger.warns)
+		}
+	})
+
+	t.Run("column policy", func(t *testing.T) {
+		withRegisteredModels(t, reflect.TypeOf(QueryInvalidUser{}))
+		defer func(policy ColumnPolicy) { db.columnPolicy = policy }(db.columnPolicy)
+
+		for _, policy := range []ColumnPolicy{ColumnPolicyIgnore, ColumnPolicyCollect | ColumnPolicyErrorOnUnknown} {
+			db.columnPolicy = policy
+			logger.warns = nil
+
+			err := ValidateQueries(ctx, db)
+			if err == nil || strings.Contains(err.Error(), "org_id") {
+				t.Errorf("%s: expected only the placeholder error, got %v", policy, err)
+			}
+			foundWarning := false
+			for _, entry := range logger.warns {
+				for i := 0; i < len(entry.keyvals)-1; i += 2 {
+					if entry.keyvals[i] == "unknown" && reflect.DeepEqual(entry.keyvals[i+1], []string{"org_id"}) {
+						foundWarning = true
+					}
+				}
+			}
+			if !foundWarning {
+				t.Errorf("%s: expected warning about unmapped org_id column, got %+v", policy, logger.warns)
+			}
+		}
+
+		db.columnPolicy = ColumnPolicyErrorOnMissing
+		if err := ValidateQueries(ctx, db); err == nil || !strings.Contains(err.Error(), "QueryByID(): query does not return db tag(s) nick") {
+			t.Errorf("Expected missing nick error, got %v", err)
+		}
+	})
+}
+
+func TestOpen_WithQueryValidation(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "validate.db")
+	setup, err := OpenWithoutValidation("sqlite3", dsn)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := setup.Exec(context.Background(), queryValidationSchema); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	if err := setup.Close(); err != nil {
+		t.Fatalf("Failed to close database: %v", err)
+	}
+
+	t.Run("succeeds with valid queries", func(t *testing.T) {
+		withRegisteredModels(t, reflect.TypeOf(QueryValidUser{}))
+		db, err := OpenWithoutValidation("sqlite3", dsn, WithQueryValidation(true))
+		if err != nil {
+			t.Fatalf("Expected open to succeed, got %v", err)
+		}
+		if err := db.Close(); err != nil {
+			t.Logf("Warning: failed to close database: %v", err)
+		}
+	})
+
+	t.Run("fails with invalid queries", func(t *testing.T) {
+		withRegisteredModels(t, reflect.TypeOf(QueryTypoUser{}))
+		db, err := OpenWithoutValidation("sqlite3", dsn, WithQueryValidation(true))
+		if err == nil {
+			t.Fatal("Expected open to fail")
+		}
+		if db != nil {
+			t.Error("Expected nil DB on failure")
+		}
+	})
+}
+
+func TestCountPlaceholders(t *testing.T) {
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"SELECT * FROM users WHERE id = ?", 1},
+		{"SELECT * FROM users WHERE a = ? AND b = ?", 2},
+		{"SELECT * FROM users WHERE a = $1 AND b = $2 OR c = $1", 2},
+		{"SELECT * FROM users WHERE a = @p1 AND b = @p2", 2},
+		{"SELECT * FROM users WHERE a = :1 AND b = :2", 2},
+		{"SELECT * FROM users WHERE a = :name AND b = :name", 1},
+		{"SELECT * FROM users WHERE a = '?' AND b = $1", 1},
+		{`SELECT "col?" FROM users WHERE id = $1`, 1},
+		{"SELECT created_at::date FROM users WHERE id = $1", 1},
+		{"SELECT @@IDENTITY, id FROM users WHERE id = @p1", 1},
+		{"SELECT id FROM users -- where x = ?\nWHERE id = ?", 1},
+		{"SELECT id /* ? */ FROM users WHERE id = ?", 1},
+		{"SELECT 'it''s ?' FROM users", 0},
+	}
+
+	for _, tt := range tests {
+		if got := countPlaceholders(tt.query); got != tt.want {
+			t.Errorf("countPlaceholders(%q) = %d, want %d", tt.query, got, tt.want)
+		}
+	}
+}